@@ -0,0 +1,192 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+var (
+	// ErrEmptySlice happens when aggregating no Money, which has no currency.
+	ErrEmptySlice = errors.New("no money to aggregate")
+
+	// ErrNilMoney happens when an element of a slice is nil or has no currency.
+	ErrNilMoney = errors.New("nil money")
+)
+
+// ElementError records the element of a slice of Money that could not be
+// aggregated and why. Err wraps ErrCurrencyMismatch or ErrNilMoney.
+type ElementError struct {
+	Index int // Index is the position of the element in the slice
+	Err   error
+}
+
+func (e *ElementError) Error() string {
+	return fmt.Sprintf("element %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ElementError) Unwrap() error {
+	return e.Err
+}
+
+// checkSlice returns an ElementError for the first element of ms that is nil
+// or has another currency than the first one, or ErrEmptySlice if ms is empty
+// and empty is false.
+func checkSlice(ms []*Money, empty bool) error {
+	if len(ms) == 0 {
+		if empty {
+			return nil
+		}
+		return ErrEmptySlice
+	}
+
+	for i, m := range ms {
+		if m == nil || m.currency == nil {
+			return &ElementError{Index: i, Err: ErrNilMoney}
+		}
+		if !m.SameCurrency(ms[0]) {
+			return &ElementError{Index: i, Err: fmt.Errorf("%w: %s, expected %s", ErrCurrencyMismatch, m.currency.Code, ms[0].currency.Code)}
+		}
+	}
+
+	return nil
+}
+
+// Sum returns the sum of ms, which must all have the same currency. It returns
+// ErrEmptySlice if ms is empty, and ErrAmountOverflow if the sum does not fit
+// into an Amount.
+func Sum(ms []*Money) (*Money, error) {
+	total, err := sum(ms)
+	if err != nil {
+		return nil, err
+	}
+	if !total.IsInt64() {
+		return nil, fmt.Errorf("%w: sum %s", ErrAmountOverflow, total)
+	}
+
+	return &Money{amount: total.Int64(), currency: ms[0].currency}, nil
+}
+
+// sum returns the exact sum of the amounts of ms.
+func sum(ms []*Money) (*big.Int, error) {
+	if err := checkSlice(ms, false); err != nil {
+		return nil, err
+	}
+
+	total := new(big.Int)
+	for _, m := range ms {
+		total.Add(total, big.NewInt(m.amount))
+	}
+
+	return total, nil
+}
+
+// Min returns the smallest of ms, the first one if several are equal. It
+// returns ErrEmptySlice if ms is empty.
+func Min(ms []*Money) (*Money, error) {
+	if err := checkSlice(ms, false); err != nil {
+		return nil, err
+	}
+
+	smallest := ms[0]
+	for _, m := range ms[1:] {
+		if m.amount < smallest.amount {
+			smallest = m
+		}
+	}
+
+	return smallest, nil
+}
+
+// Max returns the largest of ms, the first one if several are equal. It
+// returns ErrEmptySlice if ms is empty.
+func Max(ms []*Money) (*Money, error) {
+	if err := checkSlice(ms, false); err != nil {
+		return nil, err
+	}
+
+	largest := ms[0]
+	for _, m := range ms[1:] {
+		if m.amount > largest.amount {
+			largest = m
+		}
+	}
+
+	return largest, nil
+}
+
+// Average returns the mean of ms rounded to minor units using mode. It returns
+// ErrEmptySlice if ms is empty.
+func Average(ms []*Money, mode RoundingMode) (*Money, error) {
+	total, err := sum(ms)
+	if err != nil {
+		return nil, err
+	}
+
+	amount, err := roundRat(new(big.Rat).SetFrac(total, big.NewInt(int64(len(ms)))), mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Money{amount: amount, currency: ms[0].currency}, nil
+}
+
+// Median returns the middle of ms in order of amount, or the mean of the two
+// middle ones rounded to minor units using mode if ms has an even number of
+// elements. It returns ErrEmptySlice if ms is empty. ms is not reordered.
+func Median(ms []*Money, mode RoundingMode) (*Money, error) {
+	if err := checkSlice(ms, false); err != nil {
+		return nil, err
+	}
+
+	sorted := make([]*Money, len(ms))
+	copy(sorted, ms)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].amount < sorted[j].amount })
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 1 {
+		return sorted[mid], nil
+	}
+
+	return Average(sorted[mid-1:mid+1], mode)
+}
+
+// SortAscending sorts ms in place from the smallest amount to the largest,
+// keeping equal ones in their order. ms is left unchanged if its elements
+// don't all have the same currency.
+func SortAscending(ms []*Money) error {
+	if err := checkSlice(ms, true); err != nil {
+		return err
+	}
+
+	sort.SliceStable(ms, func(i, j int) bool { return ms[i].amount < ms[j].amount })
+	return nil
+}
+
+// SortDescending sorts ms in place from the largest amount to the smallest,
+// keeping equal ones in their order. ms is left unchanged if its elements
+// don't all have the same currency.
+func SortDescending(ms []*Money) error {
+	if err := checkSlice(ms, true); err != nil {
+		return err
+	}
+
+	sort.SliceStable(ms, func(i, j int) bool { return ms[i].amount > ms[j].amount })
+	return nil
+}
+
+// GroupByCurrency returns the elements of ms by the code of their currency,
+// in their order in ms. It returns an empty map if ms is empty.
+func GroupByCurrency(ms []*Money) (map[string][]*Money, error) {
+	groups := make(map[string][]*Money)
+	for i, m := range ms {
+		if m == nil || m.currency == nil {
+			return nil, &ElementError{Index: i, Err: ErrNilMoney}
+		}
+		groups[m.currency.Code] = append(groups[m.currency.Code], m)
+	}
+
+	return groups, nil
+}
@@ -0,0 +1,174 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func euros(amounts ...int64) []*Money {
+	ms := make([]*Money, len(amounts))
+	for i, a := range amounts {
+		ms[i] = New(a, EUR)
+	}
+	return ms
+}
+
+func TestAggregates(t *testing.T) {
+	tests := []struct {
+		amounts               []int64
+		sum, min, max         int64
+		average, median       int64
+		averageDown, medianUp int64
+	}{
+		{[]int64{100}, 100, 100, 100, 100, 100, 100, 100},
+		{[]int64{300, -100, 200, 101}, 501, -100, 300, 125, 151, 125, 151},
+		{[]int64{5, 1, 4, 1, 3}, 14, 1, 5, 3, 3, 2, 3},
+		{[]int64{-1, -2}, -3, -2, -1, -2, -2, -1, -2},
+	}
+
+	for _, tc := range tests {
+		ms := euros(tc.amounts...)
+
+		sum, err := Sum(ms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		min, err := Min(ms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		max, err := Max(ms)
+		if err != nil {
+			t.Fatal(err)
+		}
+		average, err := Average(ms, RoundHalfUp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		median, err := Median(ms, RoundHalfUp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		averageDown, err := Average(ms, RoundDown)
+		if err != nil {
+			t.Fatal(err)
+		}
+		medianUp, err := Median(ms, RoundUp)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		got := []int64{sum.Amount(), min.Amount(), max.Amount(), average.Amount(), median.Amount(), averageDown.Amount(), medianUp.Amount()}
+		want := []int64{tc.sum, tc.min, tc.max, tc.average, tc.median, tc.averageDown, tc.medianUp}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected sum, min, max, average, median of %v to be %v got %v", tc.amounts, want, got)
+		}
+		if sum.Currency().Code != EUR || average.Currency().Code != EUR {
+			t.Errorf("Expected %s got %s and %s", EUR, sum.Currency().Code, average.Currency().Code)
+		}
+		if amounts(ms)[0] != tc.amounts[0] {
+			t.Errorf("Expected Median not to reorder %v", tc.amounts)
+		}
+	}
+
+	ms := euros(1, 2, 1)
+	if min, _ := Min(ms); min != ms[0] {
+		t.Errorf("Expected the first smallest element")
+	}
+
+	if _, err := Sum(euros(math.MaxInt64, 1)); !errors.Is(err, ErrAmountOverflow) {
+		t.Errorf("Expected %v got %v", ErrAmountOverflow, err)
+	}
+	if average, err := Average(euros(math.MaxInt64, math.MaxInt64-1), RoundDown); err != nil || average.Amount() != math.MaxInt64-1 {
+		t.Errorf("Expected %d got %v, %v", int64(math.MaxInt64-1), average, err)
+	}
+}
+
+func TestAggregates_Errors(t *testing.T) {
+	mixed := []*Money{New(1, EUR), New(2, EUR), New(3, USD)}
+	withNil := []*Money{New(1, EUR), nil}
+
+	aggregates := map[string]func([]*Money) error{
+		"Sum":     func(ms []*Money) error { _, err := Sum(ms); return err },
+		"Min":     func(ms []*Money) error { _, err := Min(ms); return err },
+		"Max":     func(ms []*Money) error { _, err := Max(ms); return err },
+		"Average": func(ms []*Money) error { _, err := Average(ms, RoundHalfUp); return err },
+		"Median":  func(ms []*Money) error { _, err := Median(ms, RoundHalfUp); return err },
+	}
+
+	for name, f := range aggregates {
+		if err := f(nil); !errors.Is(err, ErrEmptySlice) {
+			t.Errorf("Expected %s of nothing to be %v got %v", name, ErrEmptySlice, err)
+		}
+
+		err := f(mixed)
+		var ee *ElementError
+		if !errors.As(err, &ee) || ee.Index != 2 || !errors.Is(err, ErrCurrencyMismatch) {
+			t.Errorf("Expected %s to fail on element 2 with %v got %v", name, ErrCurrencyMismatch, err)
+		}
+
+		err = f(withNil)
+		if !errors.As(err, &ee) || ee.Index != 1 || !errors.Is(err, ErrNilMoney) {
+			t.Errorf("Expected %s to fail on element 1 with %v got %v", name, ErrNilMoney, err)
+		}
+	}
+
+	if err := SortAscending(mixed); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected %v got %v", ErrCurrencyMismatch, err)
+	}
+	if got := amounts(mixed); !reflect.DeepEqual(got, []int64{1, 2, 3}) {
+		t.Errorf("Expected mixed slice to be unchanged got %v", got)
+	}
+
+	err := SortDescending(withNil)
+	if err == nil || err.Error() != "element 1: nil money" {
+		t.Errorf("Expected element 1: nil money got %v", err)
+	}
+}
+
+func TestSort(t *testing.T) {
+	ms := euros(3, -1, 2, 2, 0)
+	second := ms[2]
+
+	if err := SortAscending(ms); err != nil {
+		t.Fatal(err)
+	}
+	if got := amounts(ms); !reflect.DeepEqual(got, []int64{-1, 0, 2, 2, 3}) || ms[2] != second {
+		t.Errorf("Expected [-1 0 2 2 3] got %v", got)
+	}
+
+	if err := SortDescending(ms); err != nil {
+		t.Fatal(err)
+	}
+	if got := amounts(ms); !reflect.DeepEqual(got, []int64{3, 2, 2, 0, -1}) || ms[1] != second {
+		t.Errorf("Expected [3 2 2 0 -1] got %v", got)
+	}
+
+	if err := SortAscending(nil); err != nil {
+		t.Errorf("Expected no error sorting nothing got %v", err)
+	}
+}
+
+func TestGroupByCurrency(t *testing.T) {
+	ms := []*Money{New(1, EUR), New(2, USD), New(3, EUR), New(4, JPY)}
+
+	groups, err := GroupByCurrency(ms)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(groups) != 3 || !reflect.DeepEqual(amounts(groups[EUR]), []int64{1, 3}) ||
+		!reflect.DeepEqual(amounts(groups[USD]), []int64{2}) || groups[JPY][0] != ms[3] {
+		t.Errorf("Expected EUR [1 3], USD [2] and JPY [4] got %v", groups)
+	}
+
+	if groups, err := GroupByCurrency(nil); err != nil || len(groups) != 0 {
+		t.Errorf("Expected an empty map got %v, %v", groups, err)
+	}
+
+	var ee *ElementError
+	if _, err := GroupByCurrency([]*Money{New(1, EUR), {}}); !errors.As(err, &ee) || ee.Index != 1 {
+		t.Errorf("Expected error on element 1 got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// AllocateRat returns slice of Money structs with split Self value in the
+// exact ratios rs, such as big.NewRat(1, 3) or weights from float64 with
+// new(big.Rat).SetFloat64. The parts always sum to Self: leftover pennies go
+// to the parties with the largest remainders, as with LeftoverLargestRemainder.
+func (m *Money) AllocateRat(rs []*big.Rat) ([]*Money, error) {
+	if len(rs) == 0 {
+		return nil, errors.New("no ratios specified")
+	}
+
+	// scale the ratios to integers by the least common multiple of their denominators
+	lcm := big.NewInt(1)
+	for i, r := range rs {
+		if r == nil {
+			return nil, fmt.Errorf("ratio %d is nil", i)
+		}
+		if r.Sign() < 0 {
+			return nil, errors.New("negative ratios not allowed")
+		}
+
+		g := new(big.Int).GCD(nil, nil, lcm, r.Denom())
+		lcm.Mul(lcm, new(big.Int).Quo(r.Denom(), g))
+	}
+
+	sum := new(big.Int)
+	ratios := make([]*big.Int, len(rs))
+	for i, r := range rs {
+		ratios[i] = new(big.Int).Mul(r.Num(), new(big.Int).Quo(lcm, r.Denom()))
+		sum.Add(sum, ratios[i])
+	}
+
+	return m.allocate(LeftoverLargestRemainder, ratios, sum)
+}
+
+// AllocateByMoney returns slice of Money structs with split Self value in
+// proportion to the amounts of ms, e.g. pro-rata by the balances of customers.
+// The amounts must not be negative and have the currency of Self. The parts
+// always sum to Self, as with AllocateRat.
+func (m *Money) AllocateByMoney(ms []*Money) ([]*Money, error) {
+	rs := make([]*big.Rat, len(ms))
+	for i, om := range ms {
+		if om == nil {
+			return nil, fmt.Errorf("ratio %d is nil", i)
+		}
+		if err := m.assertSameCurrency(om); err != nil {
+			return nil, fmt.Errorf("ratio %d: %w", i, err)
+		}
+		rs[i] = new(big.Rat).SetInt64(om.amount)
+	}
+
+	return m.AllocateRat(rs)
+}
+
+// AllocatePercent returns slice of Money structs with split Self value in the
+// exact decimal percentages ps, such as "33.33", "33.33" and "33.34", which
+// must sum to 100. The parts always sum to Self, as with AllocateRat.
+func (m *Money) AllocatePercent(ps []string) ([]*Money, error) {
+	sum := new(big.Rat)
+	rs := make([]*big.Rat, len(ps))
+	for i, p := range ps {
+		r, ok := new(big.Rat).SetString(p)
+		if !ok {
+			return nil, fmt.Errorf("invalid percentage %q", p)
+		}
+		rs[i] = r
+		sum.Add(sum, r)
+	}
+
+	if len(ps) > 0 && sum.Cmp(big.NewRat(100, 1)) != 0 {
+		return nil, fmt.Errorf("percentages sum to %s, not 100", sum.FloatString(2))
+	}
+
+	return m.AllocateRat(rs)
+}
+
+// allocate splits Self in the integer ratios rs, which sum to sum, rounding the
+// parts towards zero and distributing the leftover pennies with l.
+func (m *Money) allocate(l Leftover, rs []*big.Int, sum *big.Int) ([]*Money, error) {
+	ms := make([]*Money, len(rs))
+	a := big.NewInt(m.amount)
+	for i, r := range rs {
+		ms[i] = &Money{currency: m.currency}
+		// if the sum of all ratios is zero, then we just returns zeros
+		if sum.Sign() != 0 {
+			ms[i].amount = new(big.Int).Quo(new(big.Int).Mul(a, r), sum).Int64()
+		}
+	}
+
+	if sum.Sign() == 0 {
+		return ms, nil
+	}
+
+	if err := l.distribute(m.amount, ms, rs, sum); err != nil {
+		return nil, err
+	}
+
+	return ms, nil
+}
@@ -0,0 +1,104 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+func amounts(ms []*Money) []int64 {
+	var rs []int64
+	for _, m := range ms {
+		rs = append(rs, m.amount)
+	}
+	return rs
+}
+
+func TestMoney_AllocateRat(t *testing.T) {
+	tcs := []struct {
+		amount   int64
+		ratios   []*big.Rat
+		expected []int64
+	}{
+		{100, []*big.Rat{big.NewRat(1, 3), big.NewRat(1, 3), big.NewRat(1, 3)}, []int64{34, 33, 33}},
+		{100, []*big.Rat{big.NewRat(1, 2), big.NewRat(1, 3), big.NewRat(1, 6)}, []int64{50, 33, 17}},
+		{-100, []*big.Rat{big.NewRat(1, 2), big.NewRat(1, 3), big.NewRat(1, 6)}, []int64{-50, -33, -17}},
+		{1000, []*big.Rat{new(big.Rat).SetFloat64(0.25), new(big.Rat).SetFloat64(0.125), new(big.Rat).SetFloat64(0.625)}, []int64{250, 125, 625}},
+		{10, []*big.Rat{big.NewRat(0, 1), big.NewRat(7, 10), big.NewRat(3, 1000)}, []int64{0, 10, 0}},
+		{10, []*big.Rat{new(big.Rat), new(big.Rat)}, []int64{0, 0}},
+		{9223372036854775807, []*big.Rat{big.NewRat(1, 3), big.NewRat(2, 3)}, []int64{3074457345618258602, 6148914691236517205}},
+	}
+
+	for _, tc := range tcs {
+		parties, err := New(tc.amount, EUR).AllocateRat(tc.ratios)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rs := amounts(parties); !reflect.DeepEqual(tc.expected, rs) {
+			t.Errorf("Expected allocation of %d for ratios %v to be %v got %v", tc.amount, tc.ratios, tc.expected, rs)
+		}
+	}
+
+	for _, rs := range [][]*big.Rat{nil, {big.NewRat(1, 2), nil}, {big.NewRat(-1, 2), big.NewRat(3, 2)}} {
+		if _, err := New(100, EUR).AllocateRat(rs); err == nil {
+			t.Errorf("Expected err for ratios %v", rs)
+		}
+	}
+}
+
+func TestMoney_AllocateByMoney(t *testing.T) {
+	balances := []*Money{New(15000, USD), New(0, USD), New(4999, USD), New(1, USD)}
+
+	parties, err := New(1001, USD).AllocateByMoney(balances)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs := amounts(parties); !reflect.DeepEqual([]int64{751, 0, 250, 0}, rs) {
+		t.Errorf("Expected [751 0 250 0] got %v", rs)
+	}
+	for _, p := range parties {
+		if p.Currency().Code != USD {
+			t.Errorf("Expected %s got %s", USD, p.Currency().Code)
+		}
+	}
+
+	if _, err := New(100, USD).AllocateByMoney([]*Money{New(1, USD), New(1, EUR)}); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected %v got %v", ErrCurrencyMismatch, err)
+	}
+	if _, err := New(100, USD).AllocateByMoney([]*Money{New(1, USD), New(-1, USD)}); err == nil {
+		t.Error("Expected err for negative amount")
+	}
+	if _, err := New(100, USD).AllocateByMoney([]*Money{nil}); err == nil {
+		t.Error("Expected err for nil amount")
+	}
+}
+
+func TestMoney_AllocatePercent(t *testing.T) {
+	tcs := []struct {
+		amount   int64
+		percents []string
+		expected []int64
+	}{
+		{100, []string{"33.33", "33.33", "33.34"}, []int64{33, 33, 34}},
+		{1, []string{"33.33", "33.33", "33.34"}, []int64{0, 0, 1}},
+		{99999, []string{"12.5", "87.5"}, []int64{12500, 87499}},
+		{500, []string{"100"}, []int64{500}},
+	}
+
+	for _, tc := range tcs {
+		parties, err := New(tc.amount, GBP).AllocatePercent(tc.percents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rs := amounts(parties); !reflect.DeepEqual(tc.expected, rs) {
+			t.Errorf("Expected allocation of %d for %v to be %v got %v", tc.amount, tc.percents, tc.expected, rs)
+		}
+	}
+
+	for _, ps := range [][]string{{"50", "49.99"}, {"50", "fifty"}, {}} {
+		if _, err := New(100, GBP).AllocatePercent(ps); err == nil {
+			t.Errorf("Expected err for %v", ps)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package money
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestMoney_MarshalBinary(t *testing.T) {
+	tcs := []struct {
+		money    *Money
+		expected []byte
+	}{
+		{New(1234, USD), []byte{1, 1, 0xc8, 0x06, 0xa4, 0x13}},
+		{New(-1, EUR), []byte{1, 1, 0xd2, 0x07, 0x01}},
+		{New(1, ANG), []byte{1, 0, 3, 'A', 'N', 'G', 0x02}},
+		{New(0, "FOO"), []byte{1, 0, 3, 'F', 'O', 'O', 0x00}},
+		{&Money{}, []byte{1, 0, 0, 0x00}},
+	}
+
+	for _, tc := range tcs {
+		b, err := tc.money.MarshalBinary()
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+
+		if !bytes.Equal(b, tc.expected) {
+			t.Errorf("Expected %v got %v", tc.expected, b)
+		}
+
+		var m Money
+		if err := m.UnmarshalBinary(b); err != nil {
+			t.Errorf("UnmarshalBinary(%v) error: %v", b, err)
+			continue
+		}
+		if m != (Money{}) || *tc.money != (Money{}) {
+			if eq, err := m.Equals(tc.money); err != nil || !eq {
+				t.Errorf("Expected %s got %s", tc.money.Display(), m.Display())
+			}
+		}
+	}
+}
+
+func TestMoney_UnmarshalBinary_Invalid(t *testing.T) {
+	tcs := [][]byte{
+		nil,
+		{1},
+		{2, 1, 0xc8, 0x06, 0x00},
+		{1, 9, 0x00},
+		{1, 1, 0xa0, 0x1f, 0x00},
+		{1, 1, 0x94, 0x04, 0x00},
+		{1, 0, 5, 'U', 'S', 'D', 0x00},
+		{1, 0, 3, 'U', 'S', 'D'},
+		{1, 0, 3, 'U', 'S', 'D', 0x80},
+		{1, 0, 3, 'U', 'S', 'D', 0x00, 0x00},
+	}
+
+	for _, tc := range tcs {
+		var m Money
+		if err := m.UnmarshalBinary(tc); !errors.Is(err, ErrInvalidBinaryUnmarshal) {
+			t.Errorf("UnmarshalBinary(%v) expected ErrInvalidBinaryUnmarshal, got %v", tc, err)
+		}
+	}
+}
+
+func TestMoney_Gob(t *testing.T) {
+	type payment struct {
+		Amount Money
+		Fee    *Money
+	}
+
+	given := payment{Amount: *New(1234, USD), Fee: New(-5, "FOO")}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(given); err != nil {
+		t.Fatal(err)
+	}
+
+	var got payment
+	if err := gob.NewDecoder(&buf).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+
+	if eq, err := got.Amount.Equals(&given.Amount); err != nil || !eq {
+		t.Errorf("Expected %s got %s", given.Amount.Display(), got.Amount.Display())
+	}
+	if eq, err := got.Fee.Equals(given.Fee); err != nil || !eq {
+		t.Errorf("Expected %s got %s", given.Fee.Display(), got.Fee.Display())
+	}
+}
+
+func FuzzMoney_Binary(f *testing.F) {
+	f.Add(int64(1234), USD)
+	f.Add(int64(-1), ANG)
+	f.Add(int64(0), "")
+	f.Add(int64(-9223372036854775808), "FOO")
+	f.Add(int64(9223372036854775807), "\x00\xff")
+
+	f.Fuzz(func(t *testing.T, amount int64, code string) {
+		given := New(amount, code)
+
+		b, err := given.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() error: %v", err)
+		}
+
+		var got Money
+		if err := got.UnmarshalBinary(b); err != nil {
+			t.Fatalf("UnmarshalBinary(%v) error: %v", b, err)
+		}
+
+		if got == (Money{}) {
+			if amount != 0 || given.Currency().Code != "" {
+				t.Fatalf("Expected %d %q got zero value", amount, given.Currency().Code)
+			}
+			return
+		}
+		if got.Amount() != amount || got.Currency().Code != given.Currency().Code {
+			t.Fatalf("Expected %d %q got %d %q", amount, given.Currency().Code, got.Amount(), got.Currency().Code)
+		}
+	})
+}
+
+func FuzzMoney_UnmarshalBinary(f *testing.F) {
+	f.Add([]byte{1, 1, 0xc8, 0x06, 0xa4, 0x13})
+	f.Add([]byte{1, 0, 3, 'A', 'N', 'G', 0x02})
+	f.Add([]byte{1, 0, 0xff, 0xff, 0xff, 0xff, 0x0f})
+
+	f.Fuzz(func(t *testing.T, b []byte) {
+		var m Money
+		if err := m.UnmarshalBinary(b); err != nil {
+			return
+		}
+
+		b2, err := m.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary() error: %v", err)
+		}
+
+		var m2 Money
+		if err := m2.UnmarshalBinary(b2); err != nil {
+			t.Fatalf("UnmarshalBinary(%v) error: %v", b2, err)
+		}
+		if m == (Money{}) {
+			if m2 != m {
+				t.Fatalf("Expected zero value got %+v", m2)
+			}
+			return
+		}
+		if m2.Amount() != m.Amount() || m2.Currency().Code != m.Currency().Code {
+			t.Fatalf("Expected %d %q got %d %q", m.Amount(), m.Currency().Code, m2.Amount(), m2.Currency().Code)
+		}
+	})
+}
+
+func BenchmarkMoney_Marshal(b *testing.B) {
+	m := New(123456789, USD)
+
+	b.Run("binary", func(b *testing.B) {
+		b.ReportAllocs()
+		var n int
+		for i := 0; i < b.N; i++ {
+			buf, err := m.MarshalBinary()
+			if err != nil {
+				b.Fatal(err)
+			}
+			n = len(buf)
+		}
+		b.ReportMetric(float64(n), "bytes/value")
+	})
+
+	b.Run("json", func(b *testing.B) {
+		b.ReportAllocs()
+		var n int
+		for i := 0; i < b.N; i++ {
+			buf, err := MinorUnitsJSONCodec.Marshal(*m)
+			if err != nil {
+				b.Fatal(err)
+			}
+			n = len(buf)
+		}
+		b.ReportMetric(float64(n), "bytes/value")
+	})
+}
+
+func BenchmarkMoney_Unmarshal(b *testing.B) {
+	m := New(123456789, USD)
+
+	b.Run("binary", func(b *testing.B) {
+		buf, _ := m.MarshalBinary()
+		b.ReportAllocs()
+		var om Money
+		for i := 0; i < b.N; i++ {
+			if err := om.UnmarshalBinary(buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("json", func(b *testing.B) {
+		buf, _ := json.Marshal(MinorUnitsJSON(*m))
+		b.ReportAllocs()
+		var om Money
+		for i := 0; i < b.N; i++ {
+			if err := MinorUnitsJSONCodec.Unmarshal(&om, buf); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
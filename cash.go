@@ -0,0 +1,344 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	// ErrNoDenominations happens when breaking Money of a currency without denominations into cash.
+	ErrNoDenominations = errors.New("currency has no denominations")
+
+	// ErrNoChange happens when Money cannot be made from the available banknotes and coins.
+	ErrNoChange = errors.New("amount cannot be made from the denominations")
+
+	// ErrChangeTooLarge happens when the Optimal strategy would need too large a table for an amount.
+	ErrChangeTooLarge = errors.New("amount too large for optimal change")
+)
+
+// denominations holds the values of the circulating banknotes and coins of
+// currencies in minor units, from the largest to the smallest.
+var denominations = map[string][]int64{
+	AUD: {10000, 5000, 2000, 1000, 500, 200, 100, 50, 20, 10, 5},
+	CAD: {10000, 5000, 2000, 1000, 500, 200, 100, 25, 10, 5},
+	CHF: {100000, 20000, 10000, 5000, 2000, 1000, 500, 200, 100, 50, 20, 10, 5},
+	EUR: {50000, 20000, 10000, 5000, 2000, 1000, 500, 200, 100, 50, 20, 10, 5, 2, 1},
+	GBP: {5000, 2000, 1000, 500, 200, 100, 50, 20, 10, 5, 2, 1},
+	JPY: {10000, 5000, 2000, 1000, 500, 100, 50, 10, 5, 1},
+	USD: {10000, 5000, 2000, 1000, 500, 200, 100, 25, 10, 5, 1},
+}
+
+// Denominations returns the values of the circulating banknotes and coins of
+// the currency in minor units, from the largest to the smallest, or nil if
+// none are known.
+func (c *Currency) Denominations() []int64 {
+	d := denominations[c.Code]
+	if d == nil {
+		return nil
+	}
+
+	res := make([]int64, len(d))
+	copy(res, d)
+	return res
+}
+
+// SetDenominations sets the values of the circulating banknotes and coins of
+// the currency with the given code in minor units. Non-positive values are ignored.
+func SetDenominations(code string, values ...int64) {
+	d := make([]int64, 0, len(values))
+	seen := make(map[int64]bool, len(values))
+	for _, v := range values {
+		if v > 0 && !seen[v] {
+			seen[v] = true
+			d = append(d, v)
+		}
+	}
+	sort.Slice(d, func(i, j int) bool { return d[i] > d[j] })
+
+	denominations[newCurrency(code).Code] = d
+}
+
+// Denomination is a number of banknotes or coins of the same value.
+type Denomination struct {
+	Value *Money
+	Count int64
+}
+
+// ChangeStrategy selects how MakeChange picks banknotes and coins.
+type ChangeStrategy int
+
+const (
+	// Greedy takes as many of the largest value as possible, then of the next
+	// one. It is fast and gives the fewest pieces for the denominations of
+	// real currencies with enough of each, but can fail with a limited inventory.
+	Greedy ChangeStrategy = iota
+	// Optimal searches for the fewest pieces that make the amount exactly,
+	// and only fails if no combination of the inventory does. Its cost grows
+	// with the amount in units of the gcd of the values, and it returns
+	// ErrChangeTooLarge for amounts past about 4 million such units divided
+	// by the number of values.
+	Optimal
+)
+
+// Denominations breaks Money into the fewest banknotes and coins of its
+// currency, largest first, e.g. EUR 187.35 into 100 + 50 + 20 + 10 + 5 + 2 +
+// 0.20 + 0.10 + 0.05. It returns ErrNoChange if Money is negative or cannot be
+// made from the denominations, as CHF 10.23 without RoundCash.
+func (m *Money) Denominations() ([]Denomination, error) {
+	return m.MakeChange(nil, Greedy)
+}
+
+// MakeChange breaks Money into banknotes and coins taken from inventory, the
+// available count of each value, using strategy s. The result is sorted from
+// the largest value to the smallest and leaves out unused values. If inventory
+// is nil, any number of the denominations of the currency is available.
+func (m *Money) MakeChange(inventory []Denomination, s ChangeStrategy) ([]Denomination, error) {
+	values, avail, err := m.changeInventory(inventory)
+	if err != nil {
+		return nil, err
+	}
+	if m.amount < 0 {
+		return nil, fmt.Errorf("%w: negative amount %d", ErrNoChange, m.amount)
+	}
+
+	var counts []int64
+	switch s {
+	case Greedy:
+		counts = greedyChange(m.amount, values, avail)
+	case Optimal:
+		if counts, err = optimalChange(m.amount, values, avail); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unknown change strategy %d", s)
+	}
+	if counts == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoChange, m.Display())
+	}
+
+	var res []Denomination
+	for i, c := range counts {
+		if c > 0 {
+			res = append(res, Denomination{Value: &Money{amount: values[i], currency: m.currency}, Count: c})
+		}
+	}
+
+	return res, nil
+}
+
+// changeInventory returns the distinct values of inventory from the largest to
+// the smallest and their available counts, or the denominations of the
+// currency of Money as many times as they fit into it if inventory is nil.
+func (m *Money) changeInventory(inventory []Denomination) ([]int64, []int64, error) {
+	if inventory == nil {
+		values := denominations[m.currency.Code]
+		if len(values) == 0 {
+			return nil, nil, fmt.Errorf("%w: %s", ErrNoDenominations, m.currency.Code)
+		}
+
+		avail := make([]int64, len(values))
+		for i, v := range values {
+			avail[i] = m.amount / v
+		}
+		return values, avail, nil
+	}
+
+	counts := make(map[int64]int64, len(inventory))
+	for _, d := range inventory {
+		if err := m.assertSameCurrency(d.Value); err != nil {
+			return nil, nil, err
+		}
+		if d.Value.amount <= 0 || d.Count < 0 {
+			return nil, nil, fmt.Errorf("%w: %d of %s", ErrNoChange, d.Count, d.Value.Display())
+		}
+		counts[d.Value.amount] += d.Count
+	}
+
+	values := make([]int64, 0, len(counts))
+	for v := range counts {
+		values = append(values, v)
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] > values[j] })
+
+	avail := make([]int64, len(values))
+	for i, v := range values {
+		avail[i] = counts[v]
+	}
+
+	return values, avail, nil
+}
+
+// greedyChange returns the counts of values making amount, taking as many of
+// each value as possible from the largest, or nil if it fails.
+func greedyChange(amount int64, values, avail []int64) []int64 {
+	counts := make([]int64, len(values))
+	for i, v := range values {
+		c := amount / v
+		if c > avail[i] {
+			c = avail[i]
+		}
+		counts[i] = c
+		amount -= c * v
+	}
+
+	if amount != 0 {
+		return nil
+	}
+
+	return counts
+}
+
+// maxChangeCells bounds the tables of optimalChange, the number of values
+// plus two times the amount in units of their gcd, to 16 MB.
+const maxChangeCells = 1 << 22
+
+// optimalChange returns the counts of values making amount with the fewest
+// pieces, or nil if no combination does. It is a bounded knapsack over the
+// amount in units g of the gcd of the values, taking O(len(values) * amount/g)
+// time and memory, and returns ErrChangeTooLarge rather than exceed
+// maxChangeCells. If every value is available as many times as it fits into
+// the amount, only the part of the amount not covered by the largest value in
+// any optimal result is searched.
+func optimalChange(amount int64, values, avail []int64) ([]int64, error) {
+	n := len(values)
+	counts := make([]int64, n)
+
+	var g int64
+	for i, v := range values {
+		if avail[i] > 0 {
+			g = gcd(v, g)
+		}
+	}
+	if amount == 0 {
+		return counts, nil
+	}
+	if g == 0 || amount%g != 0 {
+		return nil, nil
+	}
+
+	rest := amount
+	if unlimited(amount, values, avail) {
+		if b := exchangeBound(values); b < rest {
+			counts[0] = (rest - b) / values[0]
+			rest -= counts[0] * values[0]
+		}
+	}
+
+	target := rest / g
+	if target >= maxChangeCells/int64(n+2) {
+		return nil, fmt.Errorf("%w: %d units of %d", ErrChangeTooLarge, target, g)
+	}
+
+	// dp[s] is the fewest pieces making s units of the values so far and
+	// took[i][s] how many of values[i] it takes, or took[i] is nil if none are available
+	const none = math.MaxInt32
+	size := int(target) + 1
+	dp, next := make([]int32, size), make([]int32, size)
+	for s := 1; s < size; s++ {
+		dp[s] = none
+	}
+	took := make([][]int32, n)
+	queue := make([]int, 0, size)
+
+	// from the smallest value, so that ties are broken by taking as many of
+	// the largest values as possible
+	for i := n - 1; i >= 0; i-- {
+		// values that are not available take no part, and may not be
+		// multiples of g
+		if avail[i] == 0 {
+			continue
+		}
+		u := int(values[i] / g)
+		c := avail[i]
+		if c > target/int64(u) {
+			c = target / int64(u)
+		}
+		took[i] = make([]int32, size)
+
+		// for the sums s = r + j*u, next[s] is the least dp[r+k*u] + j-k over
+		// the last c+1 k, kept in a monotonic queue of k
+		for r := 0; r < u && r < size; r++ {
+			queue, head := queue[:0], 0
+			for j, s := 0, r; s < size; j, s = j+1, s+u {
+				if dp[s] != none {
+					f := dp[s] - int32(j)
+					for len(queue) > head && dp[r+queue[len(queue)-1]*u]-int32(queue[len(queue)-1]) > f {
+						queue = queue[:len(queue)-1]
+					}
+					queue = append(queue, j)
+				}
+				for head < len(queue) && int64(j-queue[head]) > c {
+					head++
+				}
+
+				if head == len(queue) {
+					next[s] = none
+					continue
+				}
+				k := j - queue[head]
+				next[s] = dp[r+queue[head]*u] + int32(k)
+				took[i][s] = int32(k)
+			}
+		}
+		dp, next = next, dp
+	}
+
+	if dp[target] == none {
+		return nil, nil
+	}
+	for i, s := 0, int(target); i < n; i++ {
+		if took[i] == nil {
+			continue
+		}
+		k := took[i][s]
+		counts[i] += int64(k)
+		s -= int(k) * int(values[i]/g)
+	}
+
+	return counts, nil
+}
+
+// unlimited reports whether every value is available as many times as it
+// fits into amount.
+func unlimited(amount int64, values, avail []int64) bool {
+	for i, v := range values {
+		if avail[i] < amount/v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// exchangeBound returns how much of an amount the values other than the
+// largest make at most in a result with the fewest pieces. Pieces of a value
+// adding up to a multiple of a larger value could be exchanged for fewer of
+// the larger one, so each value makes less than its least common multiple
+// with any larger value.
+func exchangeBound(values []int64) int64 {
+	var bound int64
+	for i := 1; i < len(values); i++ {
+		b := int64(math.MaxInt64)
+		for j := 0; j < i; j++ {
+			if l := values[i] / gcd(values[i], values[j]); l <= math.MaxInt64/values[j] && l*values[j]-values[i] < b {
+				b = l*values[j] - values[i]
+			}
+		}
+		if b > math.MaxInt64-bound {
+			return math.MaxInt64
+		}
+		bound += b
+	}
+
+	return bound
+}
+
+func gcd(a, b int64) int64 {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
@@ -0,0 +1,245 @@
+package money
+
+import (
+	"errors"
+	"testing"
+)
+
+func denominationsString(ds []Denomination) []int64 {
+	var res []int64
+	for _, d := range ds {
+		res = append(res, d.Value.Amount(), d.Count)
+	}
+	return res
+}
+
+func equalInt64s(a, b []int64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestMoney_Denominations(t *testing.T) {
+	tests := []struct {
+		amount int64
+		code   string
+		want   []int64 // value, count pairs
+	}{
+		{18735, EUR, []int64{10000, 1, 5000, 1, 2000, 1, 1000, 1, 500, 1, 200, 1, 20, 1, 10, 1, 5, 1}},
+		{99, USD, []int64{25, 3, 10, 2, 1, 4}},
+		{1025, CHF, []int64{1000, 1, 20, 1, 5, 1}},
+		{37000, JPY, []int64{10000, 3, 5000, 1, 2000, 1}},
+		{0, GBP, nil},
+	}
+
+	for _, tc := range tests {
+		ds, err := New(tc.amount, tc.code).Denominations()
+		if err != nil {
+			t.Fatalf("Denominations() error = %v", err)
+		}
+		if got := denominationsString(ds); !equalInt64s(got, tc.want) {
+			t.Errorf("Expected %d %s to be %v got %v", tc.amount, tc.code, tc.want, got)
+		}
+		for _, d := range ds {
+			if d.Value.Currency().Code != tc.code {
+				t.Errorf("Expected %s got %s", tc.code, d.Value.Currency().Code)
+			}
+		}
+	}
+
+	if _, err := New(1023, CHF).Denominations(); !errors.Is(err, ErrNoChange) {
+		t.Errorf("Expected %v got %v", ErrNoChange, err)
+	}
+	if _, err := New(-100, EUR).Denominations(); !errors.Is(err, ErrNoChange) {
+		t.Errorf("Expected %v got %v", ErrNoChange, err)
+	}
+	if _, err := New(100, SEK).Denominations(); !errors.Is(err, ErrNoDenominations) {
+		t.Errorf("Expected %v got %v", ErrNoDenominations, err)
+	}
+}
+
+func TestMoney_MakeChange(t *testing.T) {
+	eur := func(amount, count int64) Denomination {
+		return Denomination{Value: New(amount, EUR), Count: count}
+	}
+
+	tests := []struct {
+		name      string
+		amount    int64
+		inventory []Denomination
+		greedy    []int64
+		optimal   []int64
+	}{
+		{
+			name:      "enough of everything",
+			amount:    1880,
+			inventory: []Denomination{eur(1000, 5), eur(500, 5), eur(200, 5), eur(100, 5), eur(50, 5), eur(20, 5), eur(10, 5)},
+			greedy:    []int64{1000, 1, 500, 1, 200, 1, 100, 1, 50, 1, 20, 1, 10, 1},
+			optimal:   []int64{1000, 1, 500, 1, 200, 1, 100, 1, 50, 1, 20, 1, 10, 1},
+		},
+		{
+			name:      "greedy runs out",
+			amount:    60,
+			inventory: []Denomination{eur(50, 1), eur(20, 3)},
+			optimal:   []int64{20, 3},
+		},
+		{
+			name:      "fewest pieces",
+			amount:    600,
+			inventory: []Denomination{eur(500, 1), eur(200, 3), eur(50, 2)},
+			greedy:    []int64{500, 1, 50, 2},
+			optimal:   []int64{500, 1, 50, 2},
+		},
+		{
+			name:      "limited small coins",
+			amount:    80,
+			inventory: []Denomination{eur(50, 1), eur(20, 4), eur(10, 0)},
+			optimal:   []int64{20, 4},
+		},
+		{
+			name:      "merged inventory",
+			amount:    40,
+			inventory: []Denomination{eur(20, 1), eur(20, 1)},
+			greedy:    []int64{20, 2},
+			optimal:   []int64{20, 2},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, s := range []ChangeStrategy{Greedy, Optimal} {
+				want := tc.greedy
+				if s == Optimal {
+					want = tc.optimal
+				}
+
+				ds, err := New(tc.amount, EUR).MakeChange(tc.inventory, s)
+				if want == nil {
+					if !errors.Is(err, ErrNoChange) {
+						t.Errorf("Expected strategy %d to fail with %v got %v", s, ErrNoChange, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("MakeChange(%d) error = %v", s, err)
+				}
+				if got := denominationsString(ds); !equalInt64s(got, want) {
+					t.Errorf("Expected strategy %d to give %v got %v", s, want, got)
+				}
+			}
+		})
+	}
+
+	if _, err := New(100, EUR).MakeChange([]Denomination{{Value: New(100, USD), Count: 1}}, Optimal); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected %v got %v", ErrCurrencyMismatch, err)
+	}
+	if _, err := New(30, EUR).MakeChange([]Denomination{eur(20, 10)}, Optimal); !errors.Is(err, ErrNoChange) {
+		t.Errorf("Expected %v got %v", ErrNoChange, err)
+	}
+
+	// a value none of which is available needn't be a multiple of the others
+	ds, err := New(200, EUR).MakeChange([]Denomination{eur(200, 1), eur(50, 0)}, Optimal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := denominationsString(ds); !equalInt64s(got, []int64{200, 1}) {
+		t.Errorf("Expected [200 1] got %v", got)
+	}
+}
+
+func TestMoney_MakeChange_Unlimited(t *testing.T) {
+	SetDenominations("XCH", 1, 3, 4, 0, -1)
+	defer SetDenominations("XCH")
+
+	if d := GetCurrency(EUR).Denominations(); len(d) != 15 || d[0] != 50000 {
+		t.Errorf("Expected 15 EUR denominations got %v", d)
+	}
+	if d := newCurrency("xch").Denominations(); !equalInt64s(d, []int64{4, 3, 1}) {
+		t.Errorf("Expected [4 3 1] got %v", d)
+	}
+
+	// greedy isn't optimal for 4, 3, 1
+	greedy, err := New(6, "XCH").MakeChange(nil, Greedy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := denominationsString(greedy); !equalInt64s(got, []int64{4, 1, 1, 2}) {
+		t.Errorf("Expected [4 1 1 2] got %v", got)
+	}
+
+	optimal, err := New(6, "XCH").MakeChange(nil, Optimal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := denominationsString(optimal); !equalInt64s(got, []int64{3, 2}) {
+		t.Errorf("Expected [3 2] got %v", got)
+	}
+
+	large, err := New(123456789012, EUR).MakeChange(nil, Optimal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := denominationsString(large); !equalInt64s(got, []int64{50000, 2469135, 20000, 1, 10000, 1, 5000, 1, 2000, 2, 10, 1, 2, 1}) {
+		t.Errorf("Expected greedy breakdown got %v", got)
+	}
+}
+
+func TestMoney_MakeChange_OptimalBounded(t *testing.T) {
+	values := []int64{9973, 7919, 6007, 4099, 3011, 2003, 1009, 601, 307, 211, 97, 53, 29, 17}
+	inventory := make([]Denomination, len(values))
+	for i, v := range values {
+		inventory[i] = Denomination{Value: New(v, EUR), Count: int64(i + 3)}
+	}
+
+	if _, err := New(1190440, EUR).MakeChange(inventory, Optimal); !errors.Is(err, ErrChangeTooLarge) {
+		t.Errorf("Expected %v got %v", ErrChangeTooLarge, err)
+	}
+
+	// every amount up to 60 from 3 x 25, 2 x 10, 1 x 7 and 4 x 1 against an exhaustive search
+	small := []Denomination{
+		{Value: New(25, EUR), Count: 3},
+		{Value: New(10, EUR), Count: 2},
+		{Value: New(7, EUR), Count: 1},
+		{Value: New(1, EUR), Count: 4},
+	}
+	for amount := int64(0); amount <= 60; amount++ {
+		best := int64(-1)
+		for a := int64(0); a <= 3; a++ {
+			for b := int64(0); b <= 2; b++ {
+				for c := int64(0); c <= 1; c++ {
+					for d := int64(0); d <= 4; d++ {
+						if a*25+b*10+c*7+d == amount && (best < 0 || a+b+c+d < best) {
+							best = a + b + c + d
+						}
+					}
+				}
+			}
+		}
+
+		ds, err := New(amount, EUR).MakeChange(small, Optimal)
+		if best < 0 {
+			if !errors.Is(err, ErrNoChange) {
+				t.Errorf("Expected %d to fail with %v got %v", amount, ErrNoChange, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("MakeChange(%d) error = %v", amount, err)
+		}
+
+		var sum, pieces int64
+		for _, d := range ds {
+			sum += d.Value.Amount() * d.Count
+			pieces += d.Count
+		}
+		if sum != amount || pieces != best {
+			t.Errorf("Expected %d in %d pieces got %d in %d pieces", amount, best, sum, pieces)
+		}
+	}
+}
@@ -0,0 +1,409 @@
+package money
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// JSONCodec encodes and decodes Money in one JSON shape. Unlike the package-level
+// MarshalJSON and UnmarshalJSON injection points, a JSONCodec is a plain value,
+// so different parts of a program can use different shapes side by side.
+type JSONCodec interface {
+	// Marshal returns the JSON encoding of m.
+	Marshal(m Money) ([]byte, error)
+	// Unmarshal decodes b into m.
+	Unmarshal(m *Money, b []byte) error
+}
+
+var (
+	// DefaultJSONCodec encodes Money with the default implementations of the
+	// MarshalJSON and UnmarshalJSON injection points.
+	DefaultJSONCodec JSONCodec = jsonFuncCodec{marshal: defaultMarshalJSON, unmarshal: defaultUnmarshalJSON}
+	// MinorUnitsJSONCodec encodes Money as {"amount":1234,"currency":"USD"}.
+	MinorUnitsJSONCodec JSONCodec = jsonObjectCodec{amountKey: "amount", currencyKey: "currency"}
+	// MajorUnitsJSONCodec encodes Money as {"amount":"12.34","currency":"USD"}.
+	MajorUnitsJSONCodec JSONCodec = jsonObjectCodec{amountKey: "amount", currencyKey: "currency", major: true}
+	// StringJSONCodec encodes Money as "USD 12.34".
+	StringJSONCodec JSONCodec = jsonStringCodec{}
+	// ValueCurrencyCodeJSONCodec encodes Money as {"value":"12.34","currency_code":"USD"}.
+	ValueCurrencyCodeJSONCodec JSONCodec = jsonObjectCodec{amountKey: "value", currencyKey: "currency_code", major: true}
+)
+
+// KnownCurrencyJSONCodec returns a JSONCodec that decodes like c, but rejects
+// currency codes that are not registered in the currencies list with
+// ErrUnknownCurrency. To opt into it for every Money, overwrite the injection point:
+//
+//	money.UnmarshalJSON = money.KnownCurrencyJSONCodec(money.DefaultJSONCodec).Unmarshal
+func KnownCurrencyJSONCodec(c JSONCodec) JSONCodec {
+	return knownCurrencyJSONCodec{c}
+}
+
+// MinorUnitsJSON is Money encoded to JSON with [MinorUnitsJSONCodec].
+type MinorUnitsJSON Money
+
+// MarshalJSON is implementation of json.Marshaller
+func (m MinorUnitsJSON) MarshalJSON() ([]byte, error) {
+	return MinorUnitsJSONCodec.Marshal(Money(m))
+}
+
+// UnmarshalJSON is implementation of json.Unmarshaller
+func (m *MinorUnitsJSON) UnmarshalJSON(b []byte) error {
+	return MinorUnitsJSONCodec.Unmarshal((*Money)(m), b)
+}
+
+// Money returns m as Money.
+func (m *MinorUnitsJSON) Money() *Money {
+	return (*Money)(m)
+}
+
+// MajorUnitsJSON is Money encoded to JSON with [MajorUnitsJSONCodec].
+type MajorUnitsJSON Money
+
+// MarshalJSON is implementation of json.Marshaller
+func (m MajorUnitsJSON) MarshalJSON() ([]byte, error) {
+	return MajorUnitsJSONCodec.Marshal(Money(m))
+}
+
+// UnmarshalJSON is implementation of json.Unmarshaller
+func (m *MajorUnitsJSON) UnmarshalJSON(b []byte) error {
+	return MajorUnitsJSONCodec.Unmarshal((*Money)(m), b)
+}
+
+// Money returns m as Money.
+func (m *MajorUnitsJSON) Money() *Money {
+	return (*Money)(m)
+}
+
+// StringJSON is Money encoded to JSON with [StringJSONCodec].
+type StringJSON Money
+
+// MarshalJSON is implementation of json.Marshaller
+func (m StringJSON) MarshalJSON() ([]byte, error) {
+	return StringJSONCodec.Marshal(Money(m))
+}
+
+// UnmarshalJSON is implementation of json.Unmarshaller
+func (m *StringJSON) UnmarshalJSON(b []byte) error {
+	return StringJSONCodec.Unmarshal((*Money)(m), b)
+}
+
+// Money returns m as Money.
+func (m *StringJSON) Money() *Money {
+	return (*Money)(m)
+}
+
+// ValueCurrencyCodeJSON is Money encoded to JSON with [ValueCurrencyCodeJSONCodec].
+type ValueCurrencyCodeJSON Money
+
+// MarshalJSON is implementation of json.Marshaller
+func (m ValueCurrencyCodeJSON) MarshalJSON() ([]byte, error) {
+	return ValueCurrencyCodeJSONCodec.Marshal(Money(m))
+}
+
+// UnmarshalJSON is implementation of json.Unmarshaller
+func (m *ValueCurrencyCodeJSON) UnmarshalJSON(b []byte) error {
+	return ValueCurrencyCodeJSONCodec.Unmarshal((*Money)(m), b)
+}
+
+// Money returns m as Money.
+func (m *ValueCurrencyCodeJSON) Money() *Money {
+	return (*Money)(m)
+}
+
+// jsonFuncCodec adapts a pair of marshal and unmarshal functions to a JSONCodec.
+type jsonFuncCodec struct {
+	marshal   func(m Money) ([]byte, error)
+	unmarshal func(m *Money, b []byte) error
+}
+
+func (c jsonFuncCodec) Marshal(m Money) ([]byte, error) {
+	return c.marshal(m)
+}
+
+func (c jsonFuncCodec) Unmarshal(m *Money, b []byte) error {
+	return c.unmarshal(m, b)
+}
+
+type knownCurrencyJSONCodec struct {
+	JSONCodec
+}
+
+func (c knownCurrencyJSONCodec) Unmarshal(m *Money, b []byte) error {
+	var om Money
+	if err := c.JSONCodec.Unmarshal(&om, b); err != nil {
+		return err
+	}
+
+	// The zero value carries no currency, e.g. when decoding null or {}.
+	if om != (Money{}) && GetCurrency(om.currency.Code) == nil {
+		return fmt.Errorf("%w: %q", ErrUnknownCurrency, om.currency.Code)
+	}
+
+	*m = om
+	return nil
+}
+
+// jsonObjectCodec encodes Money as a JSON object with an amount and a currency
+// code member. The amount is a JSON integer of minor units, or a decimal string
+// of major units if major is set.
+type jsonObjectCodec struct {
+	amountKey   string
+	currencyKey string
+	major       bool
+}
+
+func (c jsonObjectCodec) Marshal(m Money) ([]byte, error) {
+	if m == (Money{}) {
+		m = *New(0, "")
+	}
+
+	amount := strconv.FormatInt(m.amount, 10)
+	if c.major {
+		amount = strconv.Quote(formatMajorUnits(m.amount, m.currency.get().Fraction))
+	}
+
+	code, err := json.Marshal(m.currency.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(fmt.Sprintf(`{"%s":%s,"%s":%s}`, c.amountKey, amount, c.currencyKey, code)), nil
+}
+
+func (c jsonObjectCodec) Unmarshal(m *Money, b []byte) error {
+	if string(bytes.TrimSpace(b)) == "null" {
+		return nil
+	}
+
+	var data map[string]json.RawMessage
+	if err := decodeStrictJSON(b, &data); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSONUnmarshal, err)
+	}
+
+	for k := range data {
+		if k != c.amountKey && k != c.currencyKey {
+			return fmt.Errorf("%w: unknown field %q", ErrInvalidJSONUnmarshal, k)
+		}
+	}
+
+	rawAmount, ok := data[c.amountKey]
+	if !ok {
+		return fmt.Errorf("%w: missing field %q", ErrInvalidJSONUnmarshal, c.amountKey)
+	}
+	rawCurrency, ok := data[c.currencyKey]
+	if !ok {
+		return fmt.Errorf("%w: missing field %q", ErrInvalidJSONUnmarshal, c.currencyKey)
+	}
+
+	var code string
+	if err := decodeStrictJSON(rawCurrency, &code); err != nil {
+		return fmt.Errorf("%w: field %q: %v", ErrInvalidJSONUnmarshal, c.currencyKey, err)
+	}
+	currency := newCurrency(code).get()
+
+	var amount Amount
+	if c.major {
+		var s string
+		if err := decodeStrictJSON(rawAmount, &s); err != nil {
+			return fmt.Errorf("%w: field %q: %v", ErrInvalidJSONUnmarshal, c.amountKey, err)
+		}
+		a, err := parseMajorUnits(s, currency.Fraction)
+		if err != nil {
+			return wrapError(ErrInvalidJSONUnmarshal, fmt.Errorf("field %q: %w", c.amountKey, err))
+		}
+		amount = a
+	} else {
+		a, err := decodeMinorUnits(rawAmount)
+		if err != nil {
+			return err
+		}
+		amount = a
+	}
+
+	// the zero Money, as Marshal encodes it
+	if code == "" {
+		if amount != 0 {
+			return fmt.Errorf("%w: empty field %q", ErrInvalidJSONUnmarshal, c.currencyKey)
+		}
+		*m = Money{}
+		return nil
+	}
+
+	*m = Money{amount: amount, currency: currency}
+	return nil
+}
+
+// jsonStringCodec encodes Money as a JSON string of the currency code followed
+// by a space and the amount in major units.
+type jsonStringCodec struct{}
+
+func (jsonStringCodec) Marshal(m Money) ([]byte, error) {
+	if m == (Money{}) {
+		m = *New(0, "")
+	}
+
+	return json.Marshal(m.currency.Code + " " + formatMajorUnits(m.amount, m.currency.get().Fraction))
+}
+
+func (jsonStringCodec) Unmarshal(m *Money, b []byte) error {
+	if string(bytes.TrimSpace(b)) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := decodeStrictJSON(b, &s); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSONUnmarshal, err)
+	}
+
+	i := strings.IndexByte(s, ' ')
+	if i < 0 {
+		return fmt.Errorf("%w: %q is not \"<currency> <amount>\"", ErrInvalidJSONUnmarshal, s)
+	}
+
+	// a zero amount without a currency code, as in " 0.00", is the zero Money
+	om, err := decodeMajorUnits(s[i+1:], s[:i])
+	if err != nil {
+		return wrapError(ErrInvalidJSONUnmarshal, err)
+	}
+
+	*m = om
+	return nil
+}
+
+// decodeMinorUnits decodes a JSON number of minor units into an Amount without
+// going through float64, so amounts above 2^53 keep their exact value. Numbers
+// with a fractional part or out of the range of an Amount are rejected.
+func decodeMinorUnits(raw json.RawMessage) (Amount, error) {
+	s := string(bytes.TrimSpace(raw))
+	if s == "" || (s[0] != '-' && (s[0] < '0' || s[0] > '9')) {
+		return 0, fmt.Errorf("%w: amount %s is not a number", ErrInvalidJSONUnmarshal, s)
+	}
+
+	a, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		return a, nil
+	}
+
+	// Integral values written with a fraction or an exponent, such as 12.0 or 1e3.
+	// An exponent beyond the digits of s and of an Amount cannot give an
+	// Amount, and is rejected before big.Rat spends time on a huge power of 10.
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		exp, err := strconv.Atoi(s[i+1:])
+		if err != nil {
+			return 0, fmt.Errorf("%w: amount %s is not a number", ErrInvalidJSONUnmarshal, s)
+		}
+		if exp > len(s)+19 {
+			return 0, fmt.Errorf("%w: amount %s overflows Amount", ErrInvalidJSONUnmarshal, s)
+		}
+		if exp < -len(s) {
+			return 0, fmt.Errorf("%w: amount %s is not an integer number of minor units", ErrInvalidJSONUnmarshal, s)
+		}
+	}
+
+	r, ok := new(big.Rat).SetString(s)
+	switch {
+	case !ok:
+		return 0, fmt.Errorf("%w: amount %s is not a number", ErrInvalidJSONUnmarshal, s)
+	case !r.IsInt():
+		return 0, fmt.Errorf("%w: amount %s is not an integer number of minor units", ErrInvalidJSONUnmarshal, s)
+	case !r.Num().IsInt64():
+		return 0, fmt.Errorf("%w: amount %s overflows Amount", ErrInvalidJSONUnmarshal, s)
+	}
+
+	return r.Num().Int64(), nil
+}
+
+// decodeStrictJSON decodes exactly one JSON value from b into v.
+func decodeStrictJSON(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("unexpected data after value")
+	}
+
+	return nil
+}
+
+var errInvalidMajorUnits = errors.New("invalid amount")
+
+// kindError is an error of a kind, such as ErrInvalidJSONUnmarshal, caused by
+// another error, such as ErrAmountOverflow. errors.Is matches both.
+type kindError struct {
+	kind error
+	err  error
+}
+
+// wrapError returns err as an error of kind.
+func wrapError(kind, err error) error {
+	return &kindError{kind: kind, err: err}
+}
+
+func (e *kindError) Error() string {
+	return e.kind.Error() + ": " + e.err.Error()
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+// formatMajorUnits returns amount as a plain decimal string in major units, e.g. "-12.34".
+func formatMajorUnits(amount Amount, fraction int) string {
+	return NewFormatter(fraction, ".", "", "", "1").Format(amount)
+}
+
+// parseMajorUnits parses a plain decimal string in major units, as returned by
+// formatMajorUnits, into an Amount. It accepts an optional leading minus sign and
+// at most fraction digits after the decimal point.
+func parseMajorUnits(s string, fraction int) (Amount, error) {
+	neg := strings.HasPrefix(s, "-")
+	if neg {
+		s = s[1:]
+	}
+
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+		if fracPart == "" {
+			return 0, fmt.Errorf("%w: %q", errInvalidMajorUnits, s)
+		}
+	}
+	if intPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("%w: %q", errInvalidMajorUnits, s)
+	}
+	if len(fracPart) > fraction {
+		return 0, fmt.Errorf("%w: %q has more than %d fractional digits", errInvalidMajorUnits, s, fraction)
+	}
+
+	digits := intPart + fracPart + strings.Repeat("0", fraction-len(fracPart))
+	a, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrAmountOverflow, s)
+	}
+	if neg {
+		a = -a
+	}
+
+	return a, nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
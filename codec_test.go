@@ -0,0 +1,180 @@
+package money
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestJSONCodec_Marshal(t *testing.T) {
+	tcs := []struct {
+		codec    JSONCodec
+		money    *Money
+		expected string
+	}{
+		{MinorUnitsJSONCodec, New(1234, USD), `{"amount":1234,"currency":"USD"}`},
+		{MinorUnitsJSONCodec, New(-1, JPY), `{"amount":-1,"currency":"JPY"}`},
+		{MajorUnitsJSONCodec, New(1234, USD), `{"amount":"12.34","currency":"USD"}`},
+		{MajorUnitsJSONCodec, New(-5, BHD), `{"amount":"-0.005","currency":"BHD"}`},
+		{MajorUnitsJSONCodec, New(1234567, JPY), `{"amount":"1234567","currency":"JPY"}`},
+		{StringJSONCodec, New(1234, USD), `"USD 12.34"`},
+		{StringJSONCodec, New(-100000, EUR), `"EUR -1000.00"`},
+		{ValueCurrencyCodeJSONCodec, New(1234, USD), `{"value":"12.34","currency_code":"USD"}`},
+		{MinorUnitsJSONCodec, &Money{}, `{"amount":0,"currency":""}`},
+	}
+
+	for _, tc := range tcs {
+		b, err := tc.codec.Marshal(*tc.money)
+		if err != nil {
+			t.Errorf("Marshal(%v) error: %v", tc.money, err)
+			continue
+		}
+
+		if string(b) != tc.expected {
+			t.Errorf("Expected %s got %s", tc.expected, string(b))
+		}
+	}
+}
+
+func TestJSONCodec_Unmarshal(t *testing.T) {
+	tcs := []struct {
+		codec    JSONCodec
+		given    string
+		amount   int64
+		currency string
+		err      bool
+	}{
+		{codec: MinorUnitsJSONCodec, given: `{"amount":1234,"currency":"USD"}`, amount: 1234, currency: USD},
+		{codec: MinorUnitsJSONCodec, given: `{"currency":"USD","amount":-9223372036854775807}`, amount: -9223372036854775807, currency: USD},
+		{codec: MinorUnitsJSONCodec, given: `{"amount":12.7,"currency":"USD"}`, err: true},
+		{codec: MinorUnitsJSONCodec, given: `{"amount":"1234","currency":"USD"}`, err: true},
+		{codec: MinorUnitsJSONCodec, given: `{"amount":1234}`, err: true},
+		{codec: MinorUnitsJSONCodec, given: `{"currency":"USD"}`, err: true},
+		{codec: MinorUnitsJSONCodec, given: `{"amount":1234,"currency":""}`, err: true},
+		{codec: MinorUnitsJSONCodec, given: `{"amount":1234,"currency":"USD","extra":1}`, err: true},
+		{codec: MinorUnitsJSONCodec, given: `{"amount":1234,"currency":"USD"} {}`, err: true},
+		{codec: MajorUnitsJSONCodec, given: `{"amount":"12.34","currency":"USD"}`, amount: 1234, currency: USD},
+		{codec: MajorUnitsJSONCodec, given: `{"amount":"-0.5","currency":"EUR"}`, amount: -50, currency: EUR},
+		{codec: MajorUnitsJSONCodec, given: `{"amount":"12","currency":"JPY"}`, amount: 12, currency: JPY},
+		{codec: MajorUnitsJSONCodec, given: `{"amount":12.34,"currency":"USD"}`, err: true},
+		{codec: MajorUnitsJSONCodec, given: `{"amount":"12.345","currency":"USD"}`, err: true},
+		{codec: MajorUnitsJSONCodec, given: `{"amount":"1,234.00","currency":"USD"}`, err: true},
+		{codec: MajorUnitsJSONCodec, given: `{"amount":"12.","currency":"USD"}`, err: true},
+		{codec: MajorUnitsJSONCodec, given: `{"amount":".5","currency":"USD"}`, err: true},
+		{codec: MajorUnitsJSONCodec, given: `{"amount":"92233720368547758.08","currency":"USD"}`, err: true},
+		{codec: StringJSONCodec, given: `"USD 12.34"`, amount: 1234, currency: USD},
+		{codec: StringJSONCodec, given: `"KWD -1.5"`, amount: -1500, currency: KWD},
+		{codec: StringJSONCodec, given: `"USD12.34"`, err: true},
+		{codec: StringJSONCodec, given: `" 12.34"`, err: true},
+		{codec: StringJSONCodec, given: `"USD  12.34"`, err: true},
+		{codec: StringJSONCodec, given: `{"amount":1234,"currency":"USD"}`, err: true},
+		{codec: ValueCurrencyCodeJSONCodec, given: `{"value":"12.34","currency_code":"USD"}`, amount: 1234, currency: USD},
+		{codec: ValueCurrencyCodeJSONCodec, given: `{"amount":"12.34","currency":"USD"}`, err: true},
+	}
+
+	for _, tc := range tcs {
+		var m Money
+		err := tc.codec.Unmarshal(&m, []byte(tc.given))
+		if tc.err {
+			if !errors.Is(err, ErrInvalidJSONUnmarshal) {
+				t.Errorf("Unmarshal(%s) expected ErrInvalidJSONUnmarshal, got %v", tc.given, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", tc.given, err)
+			continue
+		}
+
+		if m.Amount() != tc.amount || m.Currency().Code != tc.currency {
+			t.Errorf("Unmarshal(%s) expected %d %s got %d %s", tc.given, tc.amount, tc.currency, m.Amount(), m.Currency().Code)
+		}
+	}
+}
+
+func TestJSONWrappers(t *testing.T) {
+	type payment struct {
+		Minor MinorUnitsJSON        `json:"minor"`
+		Major MajorUnitsJSON        `json:"major"`
+		Str   StringJSON            `json:"str"`
+		Value ValueCurrencyCodeJSON `json:"value"`
+		Ptr   *MajorUnitsJSON       `json:"ptr,omitempty"`
+	}
+
+	m := New(1234, USD)
+	given := payment{
+		Minor: MinorUnitsJSON(*m),
+		Major: MajorUnitsJSON(*m),
+		Str:   StringJSON(*m),
+		Value: ValueCurrencyCodeJSON(*m),
+		Ptr:   (*MajorUnitsJSON)(New(5, EUR)),
+	}
+	expected := `{"minor":{"amount":1234,"currency":"USD"},"major":{"amount":"12.34","currency":"USD"},` +
+		`"str":"USD 12.34","value":{"value":"12.34","currency_code":"USD"},"ptr":{"amount":"0.05","currency":"EUR"}}`
+
+	b, err := json.Marshal(given)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expected {
+		t.Fatalf("Expected %s got %s", expected, string(b))
+	}
+
+	var got payment
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, om := range []*Money{got.Minor.Money(), got.Major.Money(), got.Str.Money(), got.Value.Money()} {
+		if eq, err := om.Equals(m); err != nil || !eq {
+			t.Errorf("Expected %s got %s", m.Display(), om.Display())
+		}
+	}
+	if got.Ptr.Money().Amount() != 5 || got.Ptr.Money().Currency().Code != EUR {
+		t.Errorf("Expected %s got %s", New(5, EUR).Display(), got.Ptr.Money().Display())
+	}
+
+	// unset fields round trip to the zero Money
+	b, err = json.Marshal(payment{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var zero payment
+	if err := json.Unmarshal(b, &zero); err != nil {
+		t.Fatalf("Unmarshal(%s) error: %v", string(b), err)
+	}
+	if zero != (payment{}) {
+		t.Errorf("Expected zero values got %+v", zero)
+	}
+}
+
+func TestJSONCodec_ZeroRoundTrip(t *testing.T) {
+	for _, codec := range []JSONCodec{MinorUnitsJSONCodec, MajorUnitsJSONCodec, StringJSONCodec, ValueCurrencyCodeJSONCodec} {
+		b, err := codec.Marshal(Money{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		m := *New(1, USD)
+		if err := codec.Unmarshal(&m, b); err != nil || m != (Money{}) {
+			t.Errorf("Expected %s to decode to the zero Money got %+v, %v", string(b), m, err)
+		}
+	}
+}
+
+func TestJSONCodec_AmountOverflow(t *testing.T) {
+	for _, tc := range []struct {
+		codec JSONCodec
+		given string
+	}{
+		{MajorUnitsJSONCodec, `{"amount":"92233720368547758.08","currency":"USD"}`},
+		{StringJSONCodec, `"USD 92233720368547758.08"`},
+	} {
+		var m Money
+		err := tc.codec.Unmarshal(&m, []byte(tc.given))
+		if !errors.Is(err, ErrInvalidJSONUnmarshal) || !errors.Is(err, ErrAmountOverflow) {
+			t.Errorf("Unmarshal(%s) expected ErrInvalidJSONUnmarshal and ErrAmountOverflow, got %v", tc.given, err)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// ErrInfeasibleAllocation happens when Money cannot be allocated within the
+// minimums, maximums and granularity of the parties.
+var ErrInfeasibleAllocation = errors.New("allocation constraints cannot be met")
+
+// Share is the ratio of a party in a constrained allocation with its optional
+// minimum and maximum amounts.
+type Share struct {
+	Ratio int
+	// Min is the least amount the party gets, or zero if nil.
+	Min *Money
+	// Max is the most amount the party gets, or unlimited if nil.
+	Max *Money
+}
+
+// AllocateConstrained returns slice of Money structs with split Self value in
+// the ratios of shares, each between the Min and Max of its share and a
+// multiple of granularity, such as 0.05 for cash or 1.00 for whole units. A
+// nil granularity is one minor unit.
+//
+// Parties are given their exact shares by ratio; shares below Min are raised
+// to it and shares above Max are capped, and the rest is redistributed amongst
+// the other parties by ratio. Parties with a zero ratio only get their Min.
+// The parts are then rounded to granularity, leftovers going to the parties
+// with the largest remainders, and always sum to Self.
+//
+// It returns ErrInfeasibleAllocation if Self is negative or not a multiple of
+// granularity, or does not fit between the sums of the minimums and maximums.
+func (m *Money) AllocateConstrained(shares []Share, granularity *Money) ([]*Money, error) {
+	if len(shares) == 0 {
+		return nil, errors.New("no ratios specified")
+	}
+
+	g := int64(1)
+	if granularity != nil {
+		if err := m.assertSameCurrency(granularity); err != nil {
+			return nil, err
+		}
+		if granularity.amount <= 0 {
+			return nil, fmt.Errorf("granularity must be positive, got %d", granularity.amount)
+		}
+		g = granularity.amount
+	}
+	if m.amount < 0 || m.amount%g != 0 {
+		return nil, fmt.Errorf("%w: %d is not a non-negative multiple of %d", ErrInfeasibleAllocation, m.amount, g)
+	}
+
+	// work in units of granularity, rounding the bounds inwards
+	parties := make([]constrainedParty, len(shares))
+	for i, s := range shares {
+		if s.Ratio < 0 {
+			return nil, errors.New("negative ratios not allowed")
+		}
+		p := constrainedParty{weight: big.NewRat(int64(s.Ratio), 1), lo: new(big.Rat)}
+		if s.Min != nil {
+			if err := m.assertSameCurrency(s.Min); err != nil {
+				return nil, fmt.Errorf("share %d: %w", i, err)
+			}
+			if s.Min.amount < 0 {
+				return nil, fmt.Errorf("share %d: negative minimum %d", i, s.Min.amount)
+			}
+			p.lo.SetInt64((s.Min.amount + g - 1) / g)
+		}
+		if s.Max != nil {
+			if err := m.assertSameCurrency(s.Max); err != nil {
+				return nil, fmt.Errorf("share %d: %w", i, err)
+			}
+			if s.Max.amount < 0 {
+				return nil, fmt.Errorf("share %d: negative maximum %d", i, s.Max.amount)
+			}
+			p.hi = big.NewRat(s.Max.amount/g, 1)
+			if p.lo.Cmp(p.hi) > 0 {
+				return nil, fmt.Errorf("%w: share %d has a minimum above its maximum", ErrInfeasibleAllocation, i)
+			}
+		}
+		parties[i] = p
+	}
+
+	t, err := waterLevel(parties, big.NewRat(m.amount/g, 1))
+	if err != nil {
+		return nil, err
+	}
+
+	// round the exact shares down and give the leftover units to the largest remainders
+	units := make([]int64, len(parties))
+	fracs := make([]*big.Rat, len(parties))
+	left := m.amount / g
+	for i, p := range parties {
+		x := p.share(t)
+		q := new(big.Int).Quo(x.Num(), x.Denom())
+		units[i] = q.Int64()
+		fracs[i] = x.Sub(x, new(big.Rat).SetInt(q))
+		left -= units[i]
+	}
+
+	order := make([]int, len(parties))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return fracs[order[i]].Cmp(fracs[order[j]]) > 0
+	})
+	for _, i := range order[:left] {
+		units[i]++
+	}
+
+	ms := make([]*Money, len(parties))
+	for i, u := range units {
+		ms[i] = &Money{amount: u * g, currency: m.currency}
+	}
+
+	return ms, nil
+}
+
+// constrainedParty is a party of a constrained allocation in units of granularity.
+type constrainedParty struct {
+	weight *big.Rat
+	lo     *big.Rat
+	// hi is nil if the party has no maximum.
+	hi *big.Rat
+}
+
+// share returns the exact share of the party at water level t, its weight
+// times t between its bounds.
+func (p constrainedParty) share(t *big.Rat) *big.Rat {
+	x := new(big.Rat).Mul(t, p.weight)
+	if x.Cmp(p.lo) < 0 {
+		x.Set(p.lo)
+	}
+	if p.hi != nil && x.Cmp(p.hi) > 0 {
+		x.Set(p.hi)
+	}
+
+	return x
+}
+
+// waterLevel returns the level t at which the shares of parties sum to n. The
+// sum grows linearly between the levels where parties reach their bounds, so t
+// is found by interpolating between the two of them around n.
+func waterLevel(parties []constrainedParty, n *big.Rat) (*big.Rat, error) {
+	total := func(t *big.Rat) *big.Rat {
+		s := new(big.Rat)
+		for _, p := range parties {
+			s.Add(s, p.share(t))
+		}
+		return s
+	}
+
+	var levels []*big.Rat
+	slope := new(big.Rat) // of the sum beyond the last level
+	for _, p := range parties {
+		if p.weight.Sign() == 0 {
+			continue
+		}
+		levels = append(levels, new(big.Rat).Quo(p.lo, p.weight))
+		if p.hi != nil {
+			levels = append(levels, new(big.Rat).Quo(p.hi, p.weight))
+		} else {
+			slope.Add(slope, p.weight)
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool { return levels[i].Cmp(levels[j]) < 0 })
+
+	prev := new(big.Rat)
+	sum := total(prev)
+	if c := sum.Cmp(n); c > 0 {
+		return nil, fmt.Errorf("%w: minimums sum to more than %s units", ErrInfeasibleAllocation, n.RatString())
+	} else if c == 0 {
+		return prev, nil
+	}
+
+	for _, l := range levels {
+		if l.Cmp(prev) <= 0 {
+			continue
+		}
+		s := total(l)
+		if s.Cmp(n) >= 0 {
+			// prev + (n - sum) * (l - prev) / (s - sum)
+			t := new(big.Rat).Sub(n, sum)
+			t.Mul(t, new(big.Rat).Sub(l, prev))
+			t.Quo(t, new(big.Rat).Sub(s, sum))
+			return t.Add(t, prev), nil
+		}
+		prev, sum = l, s
+	}
+
+	if slope.Sign() == 0 {
+		return nil, fmt.Errorf("%w: maximums sum to less than %s units", ErrInfeasibleAllocation, n.RatString())
+	}
+
+	t := new(big.Rat).Sub(n, sum)
+	t.Quo(t, slope)
+	return t.Add(t, prev), nil
+}
@@ -0,0 +1,154 @@
+package money
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestMoney_AllocateConstrained(t *testing.T) {
+	eur := func(amount int64) *Money { return New(amount, EUR) }
+
+	tcs := []struct {
+		name        string
+		amount      int64
+		shares      []Share
+		granularity *Money
+		expected    []int64
+	}{
+		{
+			name:     "no constraints",
+			amount:   100,
+			shares:   []Share{{Ratio: 1}, {Ratio: 1}, {Ratio: 1}},
+			expected: []int64{34, 33, 33},
+		},
+		{
+			name:     "minimums",
+			amount:   10000,
+			shares:   []Share{{Ratio: 1, Min: eur(100)}, {Ratio: 1, Min: eur(100)}, {Ratio: 1000, Min: eur(100)}},
+			expected: []int64{100, 100, 9800},
+		},
+		{
+			name:     "caps redistribute overflow",
+			amount:   80000,
+			shares:   []Share{{Ratio: 1, Max: eur(30000)}, {Ratio: 1, Max: eur(30000)}, {Ratio: 2, Max: eur(30000)}},
+			expected: []int64{25000, 25000, 30000},
+		},
+		{
+			name:     "cap and minimum",
+			amount:   1000,
+			shares:   []Share{{Ratio: 8, Max: eur(500)}, {Ratio: 1, Min: eur(200)}, {Ratio: 1}},
+			expected: []int64{500, 250, 250},
+		},
+		{
+			name:     "all at maximum",
+			amount:   900,
+			shares:   []Share{{Ratio: 1, Max: eur(300)}, {Ratio: 5, Max: eur(300)}, {Ratio: 2, Max: eur(300)}},
+			expected: []int64{300, 300, 300},
+		},
+		{
+			name:     "zero ratio gets its minimum",
+			amount:   1000,
+			shares:   []Share{{Ratio: 0, Min: eur(500)}, {Ratio: 1}, {Ratio: 0}},
+			expected: []int64{500, 500, 0},
+		},
+		{
+			name:        "cash granularity",
+			amount:      1000,
+			shares:      []Share{{Ratio: 1}, {Ratio: 1}, {Ratio: 1}},
+			granularity: eur(5),
+			expected:    []int64{335, 335, 330},
+		},
+		{
+			name:        "whole units",
+			amount:      10000,
+			shares:      []Share{{Ratio: 1}, {Ratio: 1}, {Ratio: 1}},
+			granularity: eur(100),
+			expected:    []int64{3400, 3300, 3300},
+		},
+		{
+			name:        "bounds rounded inwards",
+			amount:      100,
+			shares:      []Share{{Ratio: 100, Max: eur(72)}, {Ratio: 1, Min: eur(3)}},
+			granularity: eur(5),
+			expected:    []int64{70, 30},
+		},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			parties, err := eur(tc.amount).AllocateConstrained(tc.shares, tc.granularity)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rs := amounts(parties); !reflect.DeepEqual(tc.expected, rs) {
+				t.Errorf("Expected allocation of %d to be %v got %v", tc.amount, tc.expected, rs)
+			}
+		})
+	}
+}
+
+func TestMoney_AllocateConstrained_AddsUp(t *testing.T) {
+	shares := []Share{
+		{Ratio: 3, Min: New(150, CHF)},
+		{Ratio: 7, Max: New(2000, CHF)},
+		{Ratio: 11, Min: New(35, CHF), Max: New(4000, CHF)},
+		{Ratio: 0, Min: New(5, CHF)},
+		{Ratio: 13},
+	}
+
+	for amount := int64(195); amount < 20000; amount += 35 {
+		parties, err := New(amount, CHF).AllocateConstrained(shares, New(5, CHF))
+		if err != nil {
+			t.Fatalf("AllocateConstrained(%d) error = %v", amount, err)
+		}
+
+		var total int64
+		for i, p := range parties {
+			total += p.amount
+			s := shares[i]
+			if p.amount%5 != 0 || s.Min != nil && p.amount < s.Min.amount || s.Max != nil && p.amount > s.Max.amount {
+				t.Errorf("Expected party %d of %d within %+v got %d", i, amount, s, p.amount)
+			}
+		}
+		if total != amount {
+			t.Errorf("Expected parts of %d to sum to it got %d", amount, total)
+		}
+	}
+}
+
+func TestMoney_AllocateConstrained_Errors(t *testing.T) {
+	eur := func(amount int64) *Money { return New(amount, EUR) }
+
+	tcs := []struct {
+		name        string
+		amount      int64
+		shares      []Share
+		granularity *Money
+		err         error
+	}{
+		{"minimums too large", 100, []Share{{Ratio: 1, Min: eur(60)}, {Ratio: 1, Min: eur(60)}}, nil, ErrInfeasibleAllocation},
+		{"maximums too small", 100, []Share{{Ratio: 1, Max: eur(40)}, {Ratio: 1, Max: eur(40)}}, nil, ErrInfeasibleAllocation},
+		{"zero ratios only get minimums", 100, []Share{{Ratio: 0}, {Ratio: 0}}, nil, ErrInfeasibleAllocation},
+		{"minimum above maximum", 100, []Share{{Ratio: 1, Min: eur(3), Max: eur(4)}, {Ratio: 1}}, eur(5), ErrInfeasibleAllocation},
+		{"not a multiple of granularity", 102, []Share{{Ratio: 1}}, eur(5), ErrInfeasibleAllocation},
+		{"negative amount", -100, []Share{{Ratio: 1}}, nil, ErrInfeasibleAllocation},
+		{"currency", 100, []Share{{Ratio: 1, Min: New(1, USD)}}, nil, ErrCurrencyMismatch},
+		{"granularity currency", 100, []Share{{Ratio: 1}}, New(1, USD), ErrCurrencyMismatch},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := eur(tc.amount).AllocateConstrained(tc.shares, tc.granularity); !errors.Is(err, tc.err) {
+				t.Errorf("Expected %v got %v", tc.err, err)
+			}
+		})
+	}
+
+	if _, err := eur(100).AllocateConstrained(nil, nil); err == nil {
+		t.Error("Expected err for no shares")
+	}
+	if _, err := eur(100).AllocateConstrained([]Share{{Ratio: 1}}, eur(0)); err == nil {
+		t.Error("Expected err for zero granularity")
+	}
+}
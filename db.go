@@ -1,7 +1,10 @@
 package money
 
 import (
+	"bytes"
+	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -14,12 +17,41 @@ var (
 	DBMoneyValueSeparator = DefaultDBMoneyValueSeparator
 )
 
+var (
+	// ErrUnsupportedScanType happens when a database value of an unsupported type is scanned into Money or Currency.
+	ErrUnsupportedScanType = errors.New("unsupported scan type")
+
+	// ErrInvalidScanValue happens when a database value is not in a format Money or Currency can be scanned from.
+	ErrInvalidScanValue = errors.New("invalid scan value")
+
+	// ErrNoCurrency happens when Money without a currency, such as the zero Money, is stored in a database
+	// or converted to GoogleMoney.
+	ErrNoCurrency = errors.New("money has no currency")
+)
+
 const (
 	// DefaultDBMoneyValueSeparator is the default value for DBMoneyValueSeparator; can be used to reset the
 	// active separator value
 	DefaultDBMoneyValueSeparator = "|"
 )
 
+// ScanError records a database value that could not be scanned and why.
+// Err wraps ErrUnsupportedScanType, ErrInvalidScanValue or ErrUnknownCurrency.
+type ScanError struct {
+	Type string      // Type is the type scanned into, e.g. "Money"
+	Src  interface{} // Src is the scanned value, with []byte converted to string
+	Err  error
+}
+
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("scanning %#v into %s: %v", e.Src, e.Type, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
 // Value implements driver.Valuer to serialise a Money instance into a delimited string using the DBMoneyValueSeparator
 // for example: "amount|currency_code"
 func (m *Money) Value() (driver.Value, error) {
@@ -27,30 +59,41 @@ func (m *Money) Value() (driver.Value, error) {
 }
 
 // Scan implements sql.Scanner to deserialize a Money instance from a DBMoneyValueSeparator-separated string
-// for example: "amount|currency_code"
+// for example: "amount|currency_code", or from a JSON object in the format of MarshalJSON as read from JSON
+// columns. The value may be a string or []byte, and the currency may be given by its alphabetic or numeric
+// ISO 4217 code, for example "1234|840". Scan returns a *ScanError if src cannot be scanned.
 func (m *Money) Scan(src interface{}) error {
-	var amount Amount
-	currency := &Currency{}
-
-	// let's support string and int64
-	switch src.(type) {
+	var s string
+	switch v := src.(type) {
 	case string:
-		parts := strings.Split(src.(string), DBMoneyValueSeparator)
-		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-			return fmt.Errorf("%#v is not valid to scan into Money; update your query to return a money.DBMoneyValueSeparator-separated pair of \"amount%scurrency_code\"", src.(string), DBMoneyValueSeparator)
-		}
+		s = v
+	case []byte:
+		// the driver may reuse v once Scan returns
+		s = string(v)
+		src = s
+	case int64:
+		return &ScanError{Type: "Money", Src: src, Err: fmt.Errorf("%w: an integer has no currency; use MinorUnitsColumns to scan separate amount and currency columns", ErrUnsupportedScanType)}
+	default:
+		return &ScanError{Type: "Money", Src: src, Err: fmt.Errorf("%w %T; update your query to return a money.DBMoneyValueSeparator-separated pair of \"amount%scurrency_code\"", ErrUnsupportedScanType, src, DBMoneyValueSeparator)}
+	}
 
-		if a, err := strconv.ParseInt(parts[0], 10, 64); err == nil {
-			amount = a
-		} else {
-			return fmt.Errorf("scanning %#v into an Amount: %v", parts[0], err)
-		}
+	if strings.HasPrefix(strings.TrimSpace(s), "{") {
+		return m.scanJSON(s)
+	}
 
-		if err := currency.Scan(parts[1]); err != nil {
-			return fmt.Errorf("scanning %#v into a Currency: %v", parts[1], err)
-		}
-	default:
-		return fmt.Errorf("don't know how to scan %T into Money; update your query to return a money.DBMoneyValueSeparator-separated pair of \"amount%scurrency_code\"", src, DBMoneyValueSeparator)
+	parts := strings.Split(s, DBMoneyValueSeparator)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return &ScanError{Type: "Money", Src: src, Err: fmt.Errorf("%w; update your query to return a money.DBMoneyValueSeparator-separated pair of \"amount%scurrency_code\"", ErrInvalidScanValue, DBMoneyValueSeparator)}
+	}
+
+	amount, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return &ScanError{Type: "Money", Src: src, Err: fmt.Errorf("%w: amount %s is not an integer number of minor units", ErrInvalidScanValue, parts[0])}
+	}
+
+	currency, err := scanCurrency(parts[1])
+	if err != nil {
+		return &ScanError{Type: "Money", Src: src, Err: err}
 	}
 
 	// allocate new Money with the scanned amount and currency
@@ -62,24 +105,55 @@ func (m *Money) Scan(src interface{}) error {
 	return nil
 }
 
+// scanJSON deserializes Money from a JSON object using UnmarshalJSON.
+func (m *Money) scanJSON(s string) error {
+	var v Money
+	if err := UnmarshalJSON(&v, []byte(s)); err != nil {
+		return &ScanError{Type: "Money", Src: s, Err: fmt.Errorf("%w: %v", ErrInvalidScanValue, err)}
+	}
+
+	if v == (Money{}) {
+		*m = v
+		return nil
+	}
+
+	currency, err := scanCurrency(v.currency.Code)
+	if err != nil {
+		return &ScanError{Type: "Money", Src: s, Err: err}
+	}
+
+	*m = Money{amount: v.amount, currency: currency}
+	return nil
+}
+
 // Value implements driver.Valuer to serialize a Currency code into a string for saving to a database
 func (c Currency) Value() (driver.Value, error) {
 	return c.Code, nil
 }
 
-// Scan implements sql.Scanner to deserialize a Currency from a string value read from a database
+// Scan implements sql.Scanner to deserialize a Currency from a value read from a database: a string or []byte
+// holding its alphabetic or numeric ISO 4217 code, for example "USD" or "840", or an int64 numeric code.
+// Scan returns a *ScanError if src is not the code of a registered currency.
 func (c *Currency) Scan(src interface{}) error {
-	var val *Currency
-	// let's support string only
-	switch src.(type) {
+	var code string
+	switch v := src.(type) {
 	case string:
-		val = GetCurrency(src.(string))
+		code = v
+	case []byte:
+		code = string(v)
+		src = code
+	case int64:
+		if v < 0 || v > 999 {
+			return &ScanError{Type: "Currency", Src: src, Err: fmt.Errorf("%w: numeric code %d is out of range", ErrInvalidScanValue, v)}
+		}
+		code = fmt.Sprintf("%03d", v)
 	default:
-		return fmt.Errorf("%T is not a supported type for a Currency (store the Currency.Code value as a string only)", src)
+		return &ScanError{Type: "Currency", Src: src, Err: fmt.Errorf("%w %T; store the Currency.Code or Currency.NumericCode value only", ErrUnsupportedScanType, src)}
 	}
 
-	if val == nil {
-		return fmt.Errorf("GetCurrency(%#v) returned nil", src)
+	val, err := scanCurrency(code)
+	if err != nil {
+		return &ScanError{Type: "Currency", Src: src, Err: err}
 	}
 
 	// copy the value
@@ -87,3 +161,378 @@ func (c *Currency) Scan(src interface{}) error {
 
 	return nil
 }
+
+// scanCurrency returns the registered currency with the given alphabetic or numeric ISO 4217 code.
+func scanCurrency(code string) (*Currency, error) {
+	if code == "" {
+		return nil, fmt.Errorf("%w: empty currency code", ErrInvalidScanValue)
+	}
+
+	if !isDigits(code) {
+		if val := GetCurrency(code); val != nil {
+			return val, nil
+		}
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, code)
+	}
+
+	if len(code) < 3 {
+		code = strings.Repeat("0", 3-len(code)) + code
+	}
+	if val, ok := numericCurrencies[code]; ok {
+		return val, nil
+	}
+	if currencies.CurrencyByNumericCode(code) != nil {
+		return nil, fmt.Errorf("%w: numeric code %s is shared by several currencies", ErrUnknownCurrency, code)
+	}
+	return nil, fmt.Errorf("%w: numeric code %s", ErrUnknownCurrency, code)
+}
+
+// DBColumn is a single database column of Money mapped by Columns.
+type DBColumn interface {
+	sql.Scanner
+	driver.Valuer
+}
+
+// Columns maps Money onto two database columns: one for the amount and one for
+// the currency code. Unlike Money.Value and Money.Scan, this keeps the amount in
+// a column the database can aggregate, sort and index.
+//
+// Pass both columns to Scan or Exec; they can appear in any order:
+//
+//	m := &money.Money{}
+//	cols := money.MinorUnitsColumns(m)
+//	err := row.Scan(&id, cols.Amount(), cols.Currency())
+//
+//	_, err = db.Exec("INSERT INTO prices (amount, currency) VALUES ($1, $2)", cols.Amount(), cols.Currency())
+//
+// Money is updated once both columns of a row have been scanned.
+type Columns struct {
+	m     *Money
+	major bool
+
+	amount      interface{}
+	currency    *Currency
+	hasAmount   bool
+	hasCurrency bool
+}
+
+// MinorUnitsColumns returns Columns storing the amount of m as an integer of minor units, for example in a BIGINT column.
+func MinorUnitsColumns(m *Money) *Columns {
+	return &Columns{m: m}
+}
+
+// DecimalColumns returns Columns storing the amount of m as a decimal of major units,
+// for example "12.34" in a DECIMAL or NUMERIC column.
+func DecimalColumns(m *Money) *Columns {
+	return &Columns{m: m, major: true}
+}
+
+// Amount returns the amount column.
+func (c *Columns) Amount() DBColumn {
+	return amountColumn{c}
+}
+
+// Currency returns the currency code column.
+func (c *Columns) Currency() DBColumn {
+	return currencyColumn{c}
+}
+
+// resolve updates Money once both columns of a row have been scanned.
+func (c *Columns) resolve() error {
+	if !c.hasAmount || !c.hasCurrency {
+		return nil
+	}
+	c.hasAmount, c.hasCurrency = false, false
+
+	currency := c.currency
+
+	var amount Amount
+	var err error
+	if c.major {
+		amount, err = scanDecimalAmount(c.amount, currency.Fraction)
+	} else {
+		amount, err = scanMinorUnitsAmount(c.amount)
+	}
+	if err != nil {
+		return err
+	}
+
+	*c.m = Money{amount: amount, currency: currency}
+	return nil
+}
+
+// moneyCurrency returns the currency of the Money of the columns, or
+// ErrNoCurrency if it has none.
+func (c *Columns) moneyCurrency() (*Currency, error) {
+	if c.m.currency == nil {
+		return nil, ErrNoCurrency
+	}
+
+	return c.m.currency, nil
+}
+
+type amountColumn struct {
+	c *Columns
+}
+
+// Value implements driver.Valuer to serialize the amount as an int64 of minor units,
+// or as a decimal string of major units for DecimalColumns.
+func (a amountColumn) Value() (driver.Value, error) {
+	currency, err := a.c.moneyCurrency()
+	if err != nil {
+		return nil, err
+	}
+	if a.c.major {
+		return formatMajorUnits(a.c.m.amount, currency.get().Fraction), nil
+	}
+
+	return a.c.m.amount, nil
+}
+
+// Scan implements sql.Scanner to deserialize the amount.
+func (a amountColumn) Scan(src interface{}) error {
+	if b, ok := src.([]byte); ok {
+		// the driver may reuse b once Scan returns
+		src = string(b)
+	}
+
+	a.c.amount = src
+	a.c.hasAmount = true
+	return a.c.resolve()
+}
+
+type currencyColumn struct {
+	c *Columns
+}
+
+// Value implements driver.Valuer to serialize the currency code.
+func (cc currencyColumn) Value() (driver.Value, error) {
+	currency, err := cc.c.moneyCurrency()
+	if err != nil {
+		return nil, err
+	}
+
+	return currency.Code, nil
+}
+
+// Scan implements sql.Scanner to deserialize the currency like Currency.Scan.
+func (cc currencyColumn) Scan(src interface{}) error {
+	currency := &Currency{}
+	if err := currency.Scan(src); err != nil {
+		return err
+	}
+
+	cc.c.currency = currency
+	cc.c.hasCurrency = true
+	return cc.c.resolve()
+}
+
+func scanMinorUnitsAmount(src interface{}) (Amount, error) {
+	switch v := src.(type) {
+	case int64:
+		return v, nil
+	case string:
+		a, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return 0, &ScanError{Type: "Amount", Src: src, Err: fmt.Errorf("%w: not an integer number of minor units", ErrInvalidScanValue)}
+		}
+		return a, nil
+	default:
+		return 0, &ScanError{Type: "Amount", Src: src, Err: fmt.Errorf("%w %T for an amount of minor units", ErrUnsupportedScanType, src)}
+	}
+}
+
+func scanDecimalAmount(src interface{}, fraction int) (Amount, error) {
+	switch v := src.(type) {
+	case int64:
+		a := v * pow10(fraction)
+		if a/pow10(fraction) != v {
+			return 0, &ScanError{Type: "Amount", Src: src, Err: fmt.Errorf("%w: %v", ErrInvalidScanValue, ErrAmountOverflow)}
+		}
+		return a, nil
+	case string:
+		// DECIMAL columns may have a larger scale than the currency, e.g. "12.3400"
+		if i := strings.IndexByte(v, '.'); i >= 0 && len(v)-i-1 > fraction {
+			v = strings.TrimRight(v, "0")
+			v = strings.TrimSuffix(v, ".")
+		}
+		a, err := parseMajorUnits(v, fraction)
+		if err != nil {
+			return 0, &ScanError{Type: "Amount", Src: src, Err: wrapError(ErrInvalidScanValue, err)}
+		}
+		return a, nil
+	default:
+		return 0, &ScanError{Type: "Amount", Src: src, Err: fmt.Errorf("%w %T for an amount of major units", ErrUnsupportedScanType, src)}
+	}
+}
+
+// PostgresComposite is Money stored in a single column of a PostgreSQL composite
+// type of an amount in minor units and a currency code, for example:
+//
+//	CREATE TYPE money_value AS (amount BIGINT, currency TEXT);
+//
+// whose text format is "(1234,USD)".
+type PostgresComposite Money
+
+// Value implements driver.Valuer to serialise Money into the composite text format, for example "(1234,USD)".
+// It returns ErrNoCurrency for the zero Money.
+func (p PostgresComposite) Value() (driver.Value, error) {
+	if p.currency == nil {
+		return nil, ErrNoCurrency
+	}
+
+	return fmt.Sprintf("(%d,%s)", p.amount, p.currency.Code), nil
+}
+
+// Scan implements sql.Scanner to deserialize Money from the composite text format, for example "(1234,USD)".
+func (p *PostgresComposite) Scan(src interface{}) error {
+	var s string
+	switch v := src.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		return &ScanError{Type: "Money", Src: src, Err: fmt.Errorf("%w %T; update your query to return a composite of \"(amount,currency_code)\"", ErrUnsupportedScanType, src)}
+	}
+
+	var parts []string
+	if len(s) >= 2 && s[0] == '(' && s[len(s)-1] == ')' {
+		parts = strings.Split(s[1:len(s)-1], ",")
+	}
+	if len(parts) != 2 {
+		return &ScanError{Type: "Money", Src: s, Err: fmt.Errorf("%w; update your query to return a composite of \"(amount,currency_code)\"", ErrInvalidScanValue)}
+	}
+
+	amount, err := scanMinorUnitsAmount(strings.Trim(parts[0], `"`))
+	if err != nil {
+		return err
+	}
+
+	currency := &Currency{}
+	if err := currency.Scan(strings.Trim(parts[1], `"`)); err != nil {
+		return err
+	}
+
+	*p = PostgresComposite{amount: amount, currency: currency}
+	return nil
+}
+
+// Money returns p as Money.
+func (p *PostgresComposite) Money() *Money {
+	return (*Money)(p)
+}
+
+// NullMoney represents Money that may be null. NullMoney implements the
+// sql.Scanner and driver.Valuer interfaces like Money, and json.Marshaler and
+// json.Unmarshaler, mapping null to an invalid NullMoney:
+//
+//	var price money.NullMoney
+//	err := db.QueryRow("SELECT price FROM products WHERE id = $1", id).Scan(&price)
+//	if price.Valid {
+//		// use price.Money
+//	}
+type NullMoney struct {
+	Money Money
+	Valid bool // Valid is true if Money is not NULL
+}
+
+// Value implements driver.Valuer, serializing NullMoney like Money or as NULL if not valid.
+// It returns ErrNoCurrency if NullMoney is valid but holds the zero Money.
+func (n NullMoney) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if n.Money.currency == nil {
+		return nil, ErrNoCurrency
+	}
+
+	return n.Money.Value()
+}
+
+// Scan implements sql.Scanner, deserializing NullMoney like Money or as not valid from NULL.
+func (n *NullMoney) Scan(src interface{}) error {
+	if src == nil {
+		n.Money, n.Valid = Money{}, false
+		return nil
+	}
+
+	n.Valid = true
+	return n.Money.Scan(src)
+}
+
+// MarshalJSON is implementation of json.Marshaller, encoding NullMoney like Money or as null if not valid.
+func (n NullMoney) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return n.Money.MarshalJSON()
+}
+
+// UnmarshalJSON is implementation of json.Unmarshaller, decoding NullMoney like Money or as not valid from null.
+func (n *NullMoney) UnmarshalJSON(b []byte) error {
+	if string(bytes.TrimSpace(b)) == "null" {
+		n.Money, n.Valid = Money{}, false
+		return nil
+	}
+
+	if err := n.Money.UnmarshalJSON(b); err != nil {
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
+
+// NullCurrency represents a Currency that may be null. NullCurrency implements
+// the sql.Scanner and driver.Valuer interfaces like Currency, and json.Marshaler
+// and json.Unmarshaler, mapping null to an invalid NullCurrency.
+type NullCurrency struct {
+	Currency Currency
+	Valid    bool // Valid is true if Currency is not NULL
+}
+
+// Value implements driver.Valuer, serializing NullCurrency like Currency or as NULL if not valid.
+func (n NullCurrency) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+
+	return n.Currency.Value()
+}
+
+// Scan implements sql.Scanner, deserializing NullCurrency like Currency or as not valid from NULL.
+func (n *NullCurrency) Scan(src interface{}) error {
+	if src == nil {
+		n.Currency, n.Valid = Currency{}, false
+		return nil
+	}
+
+	n.Valid = true
+	return n.Currency.Scan(src)
+}
+
+// MarshalJSON is implementation of json.Marshaller, encoding NullCurrency like Currency or as null if not valid.
+func (n NullCurrency) MarshalJSON() ([]byte, error) {
+	if !n.Valid {
+		return []byte("null"), nil
+	}
+
+	return n.Currency.MarshalJSON()
+}
+
+// UnmarshalJSON is implementation of json.Unmarshaller, decoding NullCurrency like Currency or as not valid from null.
+func (n *NullCurrency) UnmarshalJSON(b []byte) error {
+	if string(bytes.TrimSpace(b)) == "null" {
+		n.Currency, n.Valid = Currency{}, false
+		return nil
+	}
+
+	if err := n.Currency.UnmarshalJSON(b); err != nil {
+		return err
+	}
+
+	n.Valid = true
+	return nil
+}
@@ -2,6 +2,8 @@ package money
 
 import (
 	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -61,6 +63,38 @@ func TestMoney_Scan(t *testing.T) {
 			separator: ",",
 			want:      New(30000, IDR),
 		},
+		{
+			src:  []byte("40|EUR"),
+			want: New(40, EUR),
+		},
+		{
+			src:  "50|978",
+			want: New(50, EUR),
+		},
+		{
+			src:  `{"amount":60,"currency":"GBP"}`,
+			want: New(60, GBP),
+		},
+		{
+			src:  []byte(` {"amount":-70,"currency":"036"}`),
+			want: New(-70, AUD),
+		},
+		{
+			src:     `{"amount":1.5,"currency":"USD"}`,
+			wantErr: true,
+		},
+		{
+			src:     `{"amount":10,"currency":"XYZ"}`,
+			wantErr: true,
+		},
+		{
+			src:     "10|532",
+			wantErr: true,
+		},
+		{
+			src:     int64(10),
+			wantErr: true,
+		},
 		{
 			src:     "10|",
 			wantErr: true,
@@ -97,6 +131,7 @@ func TestMoney_Scan(t *testing.T) {
 			} else {
 				DBMoneyValueSeparator = DefaultDBMoneyValueSeparator
 			}
+			MarshalJSON, UnmarshalJSON = defaultMarshalJSON, defaultUnmarshalJSON
 			got := &Money{}
 			if err := got.Scan(tt.src); (err != nil) != tt.wantErr {
 				t.Errorf("Scan() error = %v, wantErr %v", err, tt.wantErr)
@@ -153,3 +188,302 @@ func TestCurrency_Scan(t *testing.T) {
 		})
 	}
 }
+
+func TestMoney_ScanError(t *testing.T) {
+	DBMoneyValueSeparator = DefaultDBMoneyValueSeparator
+
+	tests := []struct {
+		src  interface{}
+		want error
+	}{
+		{src: 1.5, want: ErrUnsupportedScanType},
+		{src: int64(1234), want: ErrUnsupportedScanType},
+		{src: "1234", want: ErrInvalidScanValue},
+		{src: "12.34|USD", want: ErrInvalidScanValue},
+		{src: "1234|XYZ", want: ErrUnknownCurrency},
+		{src: []byte("1234|999"), want: ErrUnknownCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%#v", tt.src), func(t *testing.T) {
+			err := (&Money{}).Scan(tt.src)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Scan() error = %v, want %v", err, tt.want)
+			}
+
+			var se *ScanError
+			if !errors.As(err, &se) || se.Type != "Money" {
+				t.Errorf("Scan() error = %#v, want *ScanError for Money", err)
+			}
+		})
+	}
+}
+
+func TestCurrency_ScanNumeric(t *testing.T) {
+	tests := []struct {
+		src     interface{}
+		want    string
+		wantErr error
+	}{
+		{src: "840", want: USD},
+		{src: []byte("978"), want: EUR},
+		{src: []byte("EUR"), want: EUR},
+		{src: int64(36), want: AUD},
+		{src: "36", want: AUD},
+		{src: "532", wantErr: ErrUnknownCurrency},
+		{src: int64(1000), wantErr: ErrInvalidScanValue},
+		{src: "", wantErr: ErrInvalidScanValue},
+		{src: 840.0, wantErr: ErrUnsupportedScanType},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%#v", tt.src), func(t *testing.T) {
+			got := &Currency{}
+			err := got.Scan(tt.src)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Scan() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got.Code != tt.want {
+				t.Errorf("Scan() got %s, want %s", got.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestColumns(t *testing.T) {
+	tests := []struct {
+		name      string
+		columns   func(m *Money) *Columns
+		amount    interface{}
+		currency  interface{}
+		wantValue driver.Value
+		want      *Money
+		wantErr   bool
+	}{
+		{name: "minor/int64", columns: MinorUnitsColumns, amount: int64(1234), currency: "USD", wantValue: int64(1234), want: New(1234, USD)},
+		{name: "minor/bytes", columns: MinorUnitsColumns, amount: []byte("-5"), currency: []byte("EUR"), wantValue: int64(-5), want: New(-5, EUR)},
+		{name: "minor/decimal", columns: MinorUnitsColumns, amount: "12.34", currency: "USD", wantErr: true},
+		{name: "minor/float", columns: MinorUnitsColumns, amount: 12.34, currency: "USD", wantErr: true},
+		{name: "minor/unknown-currency", columns: MinorUnitsColumns, amount: int64(1), currency: "XYZ", wantErr: true},
+		{name: "decimal/string", columns: DecimalColumns, amount: "12.34", currency: "USD", wantValue: "12.34", want: New(1234, USD)},
+		{name: "decimal/bytes", columns: DecimalColumns, amount: []byte("-0.005"), currency: "KWD", wantValue: "-0.005", want: New(-5, KWD)},
+		{name: "decimal/larger-scale", columns: DecimalColumns, amount: "12.3400", currency: "USD", wantValue: "12.34", want: New(1234, USD)},
+		{name: "decimal/int64", columns: DecimalColumns, amount: int64(12), currency: "USD", wantValue: "12.00", want: New(1200, USD)},
+		{name: "decimal/JPY", columns: DecimalColumns, amount: "1234.000", currency: "JPY", wantValue: "1234", want: New(1234, JPY)},
+		{name: "decimal/too-precise", columns: DecimalColumns, amount: "12.345", currency: "USD", wantErr: true},
+		{name: "decimal/nil", columns: DecimalColumns, amount: nil, currency: "USD", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := &Money{}
+			cols := tt.columns(got)
+
+			err := cols.Currency().Scan(tt.currency)
+			if err == nil {
+				err = cols.Amount().Scan(tt.amount)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if eq, err := tt.want.Equals(got); err != nil || !eq {
+				t.Errorf("Scan() got = %s, want %s", got.Display(), tt.want.Display())
+			}
+
+			amount, err := cols.Amount().Value()
+			if err != nil {
+				t.Fatalf("Value() error = %v", err)
+			}
+			if !reflect.DeepEqual(amount, tt.wantValue) {
+				t.Errorf("Value() got = %#v, want %#v", amount, tt.wantValue)
+			}
+			code, err := cols.Currency().Value()
+			if err != nil {
+				t.Fatalf("Value() error = %v", err)
+			}
+			if code != tt.want.Currency().Code {
+				t.Errorf("Value() got = %#v, want %#v", code, tt.want.Currency().Code)
+			}
+		})
+	}
+
+	for _, cols := range []*Columns{MinorUnitsColumns(&Money{}), DecimalColumns(&Money{})} {
+		if _, err := cols.Amount().Value(); !errors.Is(err, ErrNoCurrency) {
+			t.Errorf("Value() error = %v, want %v", err, ErrNoCurrency)
+		}
+		if _, err := cols.Currency().Value(); !errors.Is(err, ErrNoCurrency) {
+			t.Errorf("Value() error = %v, want %v", err, ErrNoCurrency)
+		}
+	}
+}
+
+func TestColumns_ScanRows(t *testing.T) {
+	got := &Money{}
+	cols := DecimalColumns(got)
+
+	rows := [][2]interface{}{{"1.50", "USD"}, {"7", "JPY"}}
+	want := []*Money{New(150, USD), New(7, JPY)}
+
+	for i, row := range rows {
+		if err := cols.Amount().Scan(row[0]); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+		if err := cols.Currency().Scan(row[1]); err != nil {
+			t.Fatalf("Scan() error = %v", err)
+		}
+
+		if eq, err := want[i].Equals(got); err != nil || !eq {
+			t.Errorf("row %d: got = %s, want %s", i, got.Display(), want[i].Display())
+		}
+	}
+}
+
+func TestPostgresComposite(t *testing.T) {
+	tests := []struct {
+		src     interface{}
+		want    *Money
+		wantErr bool
+	}{
+		{src: "(1234,USD)", want: New(1234, USD)},
+		{src: []byte("(-5,EUR)"), want: New(-5, EUR)},
+		{src: `("1234","USD")`, want: New(1234, USD)},
+		{src: "1234,USD", wantErr: true},
+		{src: "(1234)", wantErr: true},
+		{src: "(12.34,USD)", wantErr: true},
+		{src: "(1234,XYZ)", wantErr: true},
+		{src: int64(1234), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%#v", tt.src), func(t *testing.T) {
+			got := &PostgresComposite{}
+			if err := got.Scan(tt.src); (err != nil) != tt.wantErr {
+				t.Fatalf("Scan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if eq, err := tt.want.Equals(got.Money()); err != nil || !eq {
+				t.Errorf("Scan() got = %s, want %s", got.Money().Display(), tt.want.Display())
+			}
+
+			v, err := PostgresComposite(*tt.want).Value()
+			if err != nil {
+				t.Fatalf("Value() error = %v", err)
+			}
+			if want := fmt.Sprintf("(%d,%s)", tt.want.Amount(), tt.want.Currency().Code); v != want {
+				t.Errorf("Value() got = %v, want %v", v, want)
+			}
+		})
+	}
+
+	if _, err := (PostgresComposite{}).Value(); !errors.Is(err, ErrNoCurrency) {
+		t.Errorf("Value() error = %v, want %v", err, ErrNoCurrency)
+	}
+}
+
+func TestNullMoney(t *testing.T) {
+	DBMoneyValueSeparator = DefaultDBMoneyValueSeparator
+
+	var n NullMoney
+	if err := n.Scan("1234|USD"); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if !n.Valid || n.Money.Amount() != 1234 || n.Money.Currency().Code != USD {
+		t.Errorf("Scan() got = %+v, want valid 1234 USD", n)
+	}
+	if v, err := n.Value(); err != nil || v != "1234|USD" {
+		t.Errorf("Value() got = %v, %v, want 1234|USD", v, err)
+	}
+
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if n.Valid || n != (NullMoney{}) {
+		t.Errorf("Scan() got = %+v, want invalid", n)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("Value() got = %v, %v, want nil", v, err)
+	}
+
+	if _, err := (NullMoney{Valid: true}).Value(); !errors.Is(err, ErrNoCurrency) {
+		t.Errorf("Value() error = %v, want %v", err, ErrNoCurrency)
+	}
+}
+
+func TestNullMoney_JSON(t *testing.T) {
+	type product struct {
+		Price NullMoney `json:"price"`
+	}
+
+	tests := []struct {
+		given product
+		want  string
+	}{
+		{given: product{Price: NullMoney{Money: *New(1234, EUR), Valid: true}}, want: `{"price":{"amount":1234,"currency":"EUR"}}`},
+		{given: product{}, want: `{"price":null}`},
+	}
+
+	MarshalJSON, UnmarshalJSON = defaultMarshalJSON, defaultUnmarshalJSON
+
+	for _, tt := range tests {
+		b, err := json.Marshal(tt.given)
+		if err != nil {
+			t.Fatalf("Marshal() error = %v", err)
+		}
+		if string(b) != tt.want {
+			t.Errorf("Marshal() got = %s, want %s", b, tt.want)
+		}
+
+		got := product{Price: NullMoney{Money: *New(1, USD), Valid: true}}
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatalf("Unmarshal() error = %v", err)
+		}
+		if got.Price.Valid != tt.given.Price.Valid || got.Price.Money.amount != tt.given.Price.Money.amount {
+			t.Errorf("Unmarshal() got = %+v, want %+v", got, tt.given)
+		}
+	}
+}
+
+func TestNullCurrency(t *testing.T) {
+	var n NullCurrency
+	if err := n.Scan("EUR"); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if !n.Valid || n.Currency != *GetCurrency(EUR) {
+		t.Errorf("Scan() got = %+v, want valid EUR", n)
+	}
+	if v, err := n.Value(); err != nil || v != EUR {
+		t.Errorf("Value() got = %v, %v, want EUR", v, err)
+	}
+
+	b, err := json.Marshal(n)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	var got NullCurrency
+	if err := json.Unmarshal(b, &got); err != nil || got != n {
+		t.Errorf("Unmarshal() got = %+v, %v, want %+v", got, err, n)
+	}
+
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if n != (NullCurrency{}) {
+		t.Errorf("Scan() got = %+v, want invalid", n)
+	}
+	if v, err := n.Value(); err != nil || v != nil {
+		t.Errorf("Value() got = %v, %v, want nil", v, err)
+	}
+
+	b, err = json.Marshal(n)
+	if err != nil || string(b) != "null" {
+		t.Errorf("Marshal() got = %s, %v, want null", b, err)
+	}
+	if err := json.Unmarshal([]byte("null"), &got); err != nil || got.Valid {
+		t.Errorf("Unmarshal() got = %+v, %v, want invalid", got, err)
+	}
+}
@@ -0,0 +1,371 @@
+// Package expr evaluates arithmetic expressions over monetary amounts, such as
+//
+//	USD 120.00 * 3 - 15%
+//	(EUR 10 + EUR 2.50) / 4
+//
+// Money literals are written as a currency code followed by an amount. The
+// amount is parsed with a [parser.Parser], so it follows the same rules as
+// [parser.AmountParser.Parse] for the given currency. Plain numbers are exact
+// decimal scalars and a number followed by '%' is a percentage.
+//
+// The supported operators, by increasing precedence, are:
+//
+//	a + b, a - b    add or subtract Money of the same currency, or scalars
+//	a * b, a / b    multiply or divide Money by a scalar or percentage, or
+//	                divide Money by Money of the same currency into a scalar
+//	-a, +a          negate or keep the sign of Money or a scalar
+//	( a )           grouping
+//
+// A percentage is a scalar that stays a percentage when negated, multiplied by
+// a scalar or divided by a number, so "10% * 2" is 20%. Added to or subtracted
+// from another percentage it is a percentage too. As the right operand of + or
+// - on Money or a number, a percentage is relative to the left operand, so
+// "USD 100 - 15%" is USD 85.00 and "10 + 15%" is 11.5. Anywhere else it is its
+// plain value, such as 0.15 in "USD 100 * 15%". A percentage cannot be the left
+// operand of + or - on Money or a number: "15% + 10" is an error.
+//
+// Adding, subtracting or dividing Money of different currencies returns an
+// error wrapping [money.ErrCurrencyMismatch], dividing by zero one wrapping
+// [money.ErrDivisionByZero], and a result out of the range of a money.Amount
+// one wrapping [money.ErrAmountOverflow], or [parser.ErrOverflow] for a literal.
+// Money is rounded to the minor unit of its currency, half away from zero, after
+// every multiplication and division.
+//
+// Every error returned while evaluating an expression is an [*Error] carrying
+// the byte offset in the input where it was detected:
+//
+//	_, err := expr.Evaluate("USD 10 + EUR 2")
+//	// expr: position 7: currencies don't match
+package expr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Rhymond/go-money"
+	"github.com/Rhymond/go-money/parser"
+)
+
+var (
+	// ErrUnexpectedChar is returned when the input contains a character that starts no token.
+	ErrUnexpectedChar = errors.New("unexpected character")
+	// ErrUnexpectedToken is returned when a token appears where the grammar does not allow it.
+	ErrUnexpectedToken = errors.New("unexpected token")
+	// ErrInvalidNumber is returned when a number literal is not a valid decimal.
+	ErrInvalidNumber = errors.New("invalid number")
+	// ErrTypeMismatch is returned when an operator is applied to operands it does not support,
+	// for example adding a scalar to Money or multiplying Money by Money.
+	ErrTypeMismatch = errors.New("type mismatch")
+	// ErrNotMoney is returned when the expression evaluates to a scalar instead of Money.
+	ErrNotMoney = errors.New("expression does not evaluate to money")
+)
+
+// Error describes a failure to evaluate an expression at a position in the input.
+type Error struct {
+	// Pos is the byte offset in the input where the error was detected.
+	Pos int
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("expr: position %d: %v", e.Pos, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Evaluator evaluates expressions using a [parser.Parser] for money literals.
+type Evaluator struct {
+	parser parser.Parser
+}
+
+// NewEvaluator returns a new [Evaluator] parsing money literals with p.
+// If p is nil, a [parser.AmountParser] with default options is used.
+func NewEvaluator(p parser.Parser) *Evaluator {
+	if p == nil {
+		p = parser.NewAmountParser()
+	}
+
+	return &Evaluator{parser: p}
+}
+
+// Evaluate evaluates s with an [Evaluator] using the default [parser.AmountParser].
+func Evaluate(s string) (*money.Money, error) {
+	return NewEvaluator(nil).Evaluate(s)
+}
+
+// Evaluate evaluates s and returns the resulting Money.
+func (e *Evaluator) Evaluate(s string) (*money.Money, error) {
+	toks, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+
+	st := &state{parser: e.parser, toks: toks}
+	v, err := st.expr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := st.peek(); t.kind != tokEOF {
+		return nil, &Error{Pos: t.pos, Err: fmt.Errorf("%w: %s", ErrUnexpectedToken, t.kind)}
+	}
+	if v.m == nil {
+		return nil, &Error{Pos: 0, Err: ErrNotMoney}
+	}
+
+	return v.m, nil
+}
+
+// value is either Money (m != nil) or an exact scalar (r != nil). Scalars
+// written as percentages have already been divided by 100 and carry pct.
+type value struct {
+	m   *money.Money
+	r   *big.Rat
+	pct bool
+}
+
+// state is a recursive descent parser over the tokens of an expression.
+type state struct {
+	parser parser.Parser
+	toks   []token
+	i      int
+}
+
+func (st *state) peek() token {
+	return st.toks[st.i]
+}
+
+func (st *state) next() token {
+	t := st.toks[st.i]
+	if t.kind != tokEOF {
+		st.i++
+	}
+	return t
+}
+
+// expr := term (('+' | '-') term)*
+func (st *state) expr() (value, error) {
+	v, err := st.term()
+	if err != nil {
+		return value{}, err
+	}
+
+	for {
+		op := st.peek()
+		if op.kind != tokPlus && op.kind != tokMinus {
+			return v, nil
+		}
+		st.next()
+
+		w, err := st.term()
+		if err != nil {
+			return value{}, err
+		}
+
+		v, err = additive(op, v, w)
+		if err != nil {
+			return value{}, &Error{Pos: op.pos, Err: err}
+		}
+	}
+}
+
+// term := unary (('*' | '/') unary)*
+func (st *state) term() (value, error) {
+	v, err := st.unary()
+	if err != nil {
+		return value{}, err
+	}
+
+	for {
+		op := st.peek()
+		if op.kind != tokStar && op.kind != tokSlash {
+			return v, nil
+		}
+		st.next()
+
+		w, err := st.unary()
+		if err != nil {
+			return value{}, err
+		}
+
+		v, err = multiplicative(op, v, w)
+		if err != nil {
+			return value{}, &Error{Pos: op.pos, Err: err}
+		}
+	}
+}
+
+// unary := ('-' | '+') unary | primary
+func (st *state) unary() (value, error) {
+	switch st.peek().kind {
+	case tokMinus:
+		op := st.next()
+		v, err := st.unary()
+		if err != nil {
+			return value{}, err
+		}
+		if v.m != nil {
+			m, err := scale(v.m, big.NewRat(-1, 1))
+			if err != nil {
+				return value{}, &Error{Pos: op.pos, Err: err}
+			}
+			return value{m: m}, nil
+		}
+		return value{r: new(big.Rat).Neg(v.r), pct: v.pct}, nil
+	case tokPlus:
+		st.next()
+		return st.unary()
+	}
+
+	return st.primary()
+}
+
+// primary := MONEY | NUMBER ['%'] | '(' expr ')'
+func (st *state) primary() (value, error) {
+	t := st.next()
+
+	switch t.kind {
+	case tokMoney:
+		a, err := st.parser.Parse(t.text, t.code)
+		if err != nil {
+			return value{}, &Error{Pos: t.pos, Err: err}
+		}
+		c := money.GetCurrency(t.code)
+		if c == nil {
+			return value{}, &Error{Pos: t.pos, Err: fmt.Errorf("%w: %s", parser.ErrInvalidISO, t.code)}
+		}
+		return value{m: money.New(a, c.Code)}, nil
+	case tokNumber:
+		r, ok := new(big.Rat).SetString(t.text)
+		if !ok {
+			return value{}, &Error{Pos: t.pos, Err: fmt.Errorf("%w: %q", ErrInvalidNumber, t.text)}
+		}
+		if st.peek().kind == tokPercent {
+			st.next()
+			return value{r: r.Quo(r, big.NewRat(100, 1)), pct: true}, nil
+		}
+		return value{r: r}, nil
+	case tokLParen:
+		v, err := st.expr()
+		if err != nil {
+			return value{}, err
+		}
+		if c := st.next(); c.kind != tokRParen {
+			return value{}, &Error{Pos: c.pos, Err: fmt.Errorf("%w: %s, expected ')'", ErrUnexpectedToken, c.kind)}
+		}
+		return v, nil
+	}
+
+	return value{}, &Error{Pos: t.pos, Err: fmt.Errorf("%w: %s", ErrUnexpectedToken, t.kind)}
+}
+
+func additive(op token, v, w value) (value, error) {
+	switch {
+	case v.m != nil && w.m != nil:
+		if !v.m.SameCurrency(w.m) {
+			return value{}, money.ErrCurrencyMismatch
+		}
+		a, b := big.NewInt(v.m.Amount()), big.NewInt(w.m.Amount())
+		if op.kind == tokMinus {
+			a.Sub(a, b)
+		} else {
+			a.Add(a, b)
+		}
+		if !a.IsInt64() {
+			return value{}, money.ErrAmountOverflow
+		}
+		return value{m: money.New(a.Int64(), v.m.Currency().Code)}, nil
+	case v.m != nil && w.pct:
+		d, err := scale(v.m, w.r)
+		if err != nil {
+			return value{}, err
+		}
+		return additive(op, v, value{m: d})
+	case v.r != nil && w.r != nil && (w.pct || !v.pct):
+		d := w.r
+		if w.pct && !v.pct {
+			d = new(big.Rat).Mul(v.r, w.r)
+		}
+		if op.kind == tokMinus {
+			return value{r: new(big.Rat).Sub(v.r, d), pct: v.pct}, nil
+		}
+		return value{r: new(big.Rat).Add(v.r, d), pct: v.pct}, nil
+	}
+
+	return value{}, fmt.Errorf("%w: cannot apply %s to %s and %s", ErrTypeMismatch, op.kind, v.kind(), w.kind())
+}
+
+func multiplicative(op token, v, w value) (value, error) {
+	if op.kind == tokStar {
+		switch {
+		case v.m != nil && w.r != nil:
+			m, err := scale(v.m, w.r)
+			return value{m: m}, err
+		case v.r != nil && w.m != nil:
+			m, err := scale(w.m, v.r)
+			return value{m: m}, err
+		case v.r != nil && w.r != nil:
+			return value{r: new(big.Rat).Mul(v.r, w.r), pct: v.pct || w.pct}, nil
+		}
+
+		return value{}, fmt.Errorf("%w: cannot apply %s to %s and %s", ErrTypeMismatch, op.kind, v.kind(), w.kind())
+	}
+
+	switch {
+	case v.m != nil && w.r != nil:
+		if w.r.Sign() == 0 {
+			return value{}, money.ErrDivisionByZero
+		}
+		m, err := scale(v.m, new(big.Rat).Inv(w.r))
+		return value{m: m}, err
+	case v.m != nil && w.m != nil:
+		if !v.m.SameCurrency(w.m) {
+			return value{}, money.ErrCurrencyMismatch
+		}
+		if w.m.IsZero() {
+			return value{}, money.ErrDivisionByZero
+		}
+		return value{r: big.NewRat(v.m.Amount(), w.m.Amount())}, nil
+	case v.r != nil && w.r != nil:
+		if w.r.Sign() == 0 {
+			return value{}, money.ErrDivisionByZero
+		}
+		return value{r: new(big.Rat).Quo(v.r, w.r), pct: v.pct && !w.pct}, nil
+	}
+
+	return value{}, fmt.Errorf("%w: cannot apply %s to %s and %s", ErrTypeMismatch, op.kind, v.kind(), w.kind())
+}
+
+func (v value) kind() string {
+	switch {
+	case v.m != nil:
+		return "money"
+	case v.pct:
+		return "percentage"
+	}
+	return "number"
+}
+
+// scale returns m multiplied by r, rounded half away from zero to the minor unit.
+func scale(m *money.Money, r *big.Rat) (*money.Money, error) {
+	p := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount()), r)
+
+	n := new(big.Int).Abs(p.Num())
+	q, rem := n.QuoRem(n, p.Denom(), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(p.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if p.Sign() < 0 {
+		q.Neg(q)
+	}
+	if !q.IsInt64() {
+		return nil, money.ErrAmountOverflow
+	}
+
+	return money.New(q.Int64(), m.Currency().Code), nil
+}
@@ -0,0 +1,139 @@
+package expr
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+	"github.com/Rhymond/go-money/parser"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in   string
+		want *money.Money
+	}{
+		{in: "USD 120.00", want: money.New(12000, money.USD)},
+		{in: "usd 1,234.56", want: money.New(123456, money.USD)},
+		{in: "USD 120.00 * 3 - 15%", want: money.New(30600, money.USD)},
+		{in: "(EUR 10 + EUR 2.50) / 4", want: money.New(313, money.EUR)},
+		{in: "EUR 10 + EUR 2.50 / 4", want: money.New(1063, money.EUR)},
+		{in: "USD 100 + 15%", want: money.New(11500, money.USD)},
+		{in: "USD 100 * 15%", want: money.New(1500, money.USD)},
+		{in: "3 * USD 1.01", want: money.New(303, money.USD)},
+		{in: "USD 1 * 1.005", want: money.New(101, money.USD)},
+		{in: "-USD 1.25", want: money.New(-125, money.USD)},
+		{in: "-(USD 1 - USD 2)", want: money.New(100, money.USD)},
+		{in: "-USD 1 / 3", want: money.New(-33, money.USD)},
+		{in: "-USD 0.05 / 2", want: money.New(-3, money.USD)},
+		{in: "USD 10 * (1 + 10%)", want: money.New(1100, money.USD)},
+		{in: "USD 10 * (EUR 6 / EUR 3)", want: money.New(2000, money.USD)},
+		{in: "USD 100 + 10% * 2", want: money.New(12000, money.USD)},
+		{in: "USD 100 + 2 * 10%", want: money.New(12000, money.USD)},
+		{in: "USD 100 - 30% / 3", want: money.New(9000, money.USD)},
+		{in: "USD 100 + -10%", want: money.New(9000, money.USD)},
+		{in: "USD 100 + (10% + 5%)", want: money.New(11500, money.USD)},
+		{in: "USD 100 + 10% + 5%", want: money.New(11550, money.USD)},
+		{in: "USD 100 * (10 + 15%)", want: money.New(115000, money.USD)},
+		{in: "USD 100 * (20% / 10%)", want: money.New(20000, money.USD)},
+		{in: "USD 100 * (2 / 10%)", want: money.New(200000, money.USD)},
+		{in: "JPY 1,000 / 3", want: money.New(333, money.JPY)},
+		{in: " ( ( JPY 5 ) ) ", want: money.New(5, money.JPY)},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.in, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Evaluate(c.in)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) unexpected error: %v", c.in, err)
+			}
+			if eq, err := got.Equals(c.want); err != nil || !eq {
+				t.Errorf("Evaluate(%q) = %s %d, want %s %d", c.in, got.Currency().Code, got.Amount(), c.want.Currency().Code, c.want.Amount())
+			}
+		})
+	}
+}
+
+func TestEvaluate_Errors(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		in  string
+		pos int
+		err error
+	}{
+		{in: "USD 10 + EUR 2", pos: 7, err: money.ErrCurrencyMismatch},
+		{in: "USD 10 - EUR 2", pos: 7, err: money.ErrCurrencyMismatch},
+		{in: "USD 10 / EUR 2", pos: 7, err: money.ErrCurrencyMismatch},
+		{in: "USD 10 + 2", pos: 7, err: ErrTypeMismatch},
+		{in: "USD 10 * USD 2", pos: 7, err: ErrTypeMismatch},
+		{in: "2 / USD 10", pos: 2, err: ErrTypeMismatch},
+		{in: "15% + 10", pos: 4, err: ErrTypeMismatch},
+		{in: "USD 1 * (15% - 10)", pos: 13, err: ErrTypeMismatch},
+		{in: "15% + USD 1", pos: 4, err: ErrTypeMismatch},
+		{in: "USD 10 / 0", pos: 7, err: money.ErrDivisionByZero},
+		{in: "USD 10 / 0%", pos: 7, err: money.ErrDivisionByZero},
+		{in: "USD 10 / (USD 1 - USD 1)", pos: 7, err: money.ErrDivisionByZero},
+		{in: "USD 10 # 2", pos: 7, err: ErrUnexpectedChar},
+		{in: "USD + 2", pos: 4, err: ErrUnexpectedToken},
+		{in: "(USD 10", pos: 7, err: ErrUnexpectedToken},
+		{in: "USD 10)", pos: 6, err: ErrUnexpectedToken},
+		{in: "USD 10 *", pos: 8, err: ErrUnexpectedToken},
+		{in: "", pos: 0, err: ErrUnexpectedToken},
+		{in: "1.2.3 * USD 1", pos: 0, err: ErrInvalidNumber},
+		{in: "ZZZ 10", pos: 0, err: parser.ErrInvalidISO},
+		{in: "USD 1.234", pos: 0, err: parser.ErrTooManyDecimals},
+		{in: "USD 92,233,720,368,547,758.07 * 2", pos: 30, err: money.ErrAmountOverflow},
+		{in: "USD 92,233,720,368,547,758.07 + USD 0.01", pos: 30, err: money.ErrAmountOverflow},
+		{in: "USD 0 - USD 92,233,720,368,547,758.07 - USD 0.02", pos: 38, err: money.ErrAmountOverflow},
+		{in: "-(USD 0 - USD 92,233,720,368,547,758.07 - USD 0.01)", pos: 0, err: money.ErrAmountOverflow},
+		{in: "USD 92,233,720,368,547,758.08", pos: 0, err: parser.ErrOverflow},
+		{in: "2 * 3", pos: 0, err: ErrNotMoney},
+		{in: "USD 6 / USD 3", pos: 0, err: ErrNotMoney},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.in, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Evaluate(c.in)
+			if !errors.Is(err, c.err) {
+				t.Fatalf("Evaluate(%q) error = %v, want errors.Is(...,%v)", c.in, err, c.err)
+			}
+
+			var e *Error
+			if !errors.As(err, &e) {
+				t.Fatalf("Evaluate(%q) error = %T, want *Error", c.in, err)
+			}
+			if e.Pos != c.pos {
+				t.Errorf("Evaluate(%q) error position = %d, want %d", c.in, e.Pos, c.pos)
+			}
+		})
+	}
+}
+
+type fixedParser struct {
+	amount money.Amount
+}
+
+func (p fixedParser) Parse(string, string) (money.Amount, error) {
+	return p.amount, nil
+}
+
+func TestNewEvaluator_WithParser(t *testing.T) {
+	t.Parallel()
+
+	got, err := NewEvaluator(fixedParser{amount: 7}).Evaluate("USD 1 + USD 2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Amount() != 14 {
+		t.Errorf("got %d, want %d", got.Amount(), 14)
+	}
+}
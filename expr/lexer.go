@@ -0,0 +1,146 @@
+package expr
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokMoney
+	tokNumber
+	tokPlus
+	tokMinus
+	tokStar
+	tokSlash
+	tokPercent
+	tokLParen
+	tokRParen
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokEOF:
+		return "end of input"
+	case tokMoney:
+		return "money literal"
+	case tokNumber:
+		return "number"
+	case tokPlus:
+		return "'+'"
+	case tokMinus:
+		return "'-'"
+	case tokStar:
+		return "'*'"
+	case tokSlash:
+		return "'/'"
+	case tokPercent:
+		return "'%'"
+	case tokLParen:
+		return "'('"
+	case tokRParen:
+		return "')'"
+	default:
+		return fmt.Sprintf("token(%d)", int(k))
+	}
+}
+
+// token is a lexical unit of an expression. For money literals, code holds
+// the currency code and text the amount; for numbers, text holds the digits.
+type token struct {
+	kind tokenKind
+	pos  int
+	code string
+	text string
+}
+
+const minusSign = '−'
+
+// tokenize splits s into tokens, always terminated by a tokEOF token.
+func tokenize(s string) ([]token, error) {
+	var toks []token
+
+	for i := 0; i < len(s); {
+		r, size := utf8.DecodeRuneInString(s[i:])
+
+		switch {
+		case isSpace(r):
+			i += size
+		case r == '+':
+			toks = append(toks, token{kind: tokPlus, pos: i})
+			i += size
+		case r == '-' || r == minusSign:
+			toks = append(toks, token{kind: tokMinus, pos: i})
+			i += size
+		case r == '*':
+			toks = append(toks, token{kind: tokStar, pos: i})
+			i += size
+		case r == '/':
+			toks = append(toks, token{kind: tokSlash, pos: i})
+			i += size
+		case r == '%':
+			toks = append(toks, token{kind: tokPercent, pos: i})
+			i += size
+		case r == '(':
+			toks = append(toks, token{kind: tokLParen, pos: i})
+			i += size
+		case r == ')':
+			toks = append(toks, token{kind: tokRParen, pos: i})
+			i += size
+		case isDigit(r) || r == '.':
+			j := scan(s, i, isNumberChar)
+			toks = append(toks, token{kind: tokNumber, pos: i, text: s[i:j]})
+			i = j
+		case isLetter(r):
+			j := scan(s, i, isLetter)
+			code := s[i:j]
+
+			k := scan(s, j, isSpace)
+			l := scan(s, k, isAmountChar)
+			if l == k {
+				return nil, &Error{Pos: k, Err: fmt.Errorf("%w: currency %s without amount", ErrUnexpectedToken, code)}
+			}
+
+			toks = append(toks, token{kind: tokMoney, pos: i, code: code, text: s[k:l]})
+			i = l
+		default:
+			return nil, &Error{Pos: i, Err: fmt.Errorf("%w: %q", ErrUnexpectedChar, r)}
+		}
+	}
+
+	return append(toks, token{kind: tokEOF, pos: len(s)}), nil
+}
+
+// scan returns the offset of the first rune at or after i in s that does not satisfy f.
+func scan(s string, i int, f func(rune) bool) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !f(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
+
+func isSpace(r rune) bool {
+	return r == ' ' || r == '\t' || r == '\n' || r == '\r' || r == '\u00a0'
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isNumberChar(r rune) bool {
+	return isDigit(r) || r == '.'
+}
+
+func isAmountChar(r rune) bool {
+	return isDigit(r) || r == '.' || r == ','
+}
@@ -0,0 +1,158 @@
+package finance
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Rhymond/go-money"
+)
+
+// Method is how a loan is repaid.
+type Method int
+
+const (
+	// Annuity loans are repaid in equal payments of interest and principal,
+	// with more interest in the first ones.
+	Annuity Method = iota
+	// Linear loans are repaid in equal principal repayments plus the interest
+	// on the balance, so the payments decrease.
+	Linear
+)
+
+// Loan is a loan repaid in regular payments.
+type Loan struct {
+	Principal *money.Money
+	// AnnualRate is the exact nominal annual interest rate as a percentage,
+	// e.g. 5.5 for 5.5%. The rate of a period is AnnualRate divided by the
+	// periods per year of Frequency.
+	AnnualRate *big.Rat
+	// Periods is the term of the loan in payments.
+	Periods   int
+	Frequency Frequency
+	Method    Method
+}
+
+// Payment is a period of an amortization schedule. Interest plus Principal
+// equals Payment, and Balance is what is left of the principal after it.
+type Payment struct {
+	// Period starts at 1.
+	Period    int
+	Payment   *money.Money
+	Interest  *money.Money
+	Principal *money.Money
+	Balance   *money.Money
+}
+
+// Schedule is the amortization schedule of a loan. The principal repayments
+// of Payments sum to the principal of the loan, and the Balance of the last
+// one is zero.
+type Schedule struct {
+	Payments      []Payment
+	TotalPayment  *money.Money
+	TotalInterest *money.Money
+}
+
+// periodicRate returns the exact interest rate of a period of the loan as a
+// fraction, e.g. 11/2400 for 5.5% paid monthly.
+func (l *Loan) periodicRate() *big.Rat {
+	r := new(big.Rat).Set(l.AnnualRate)
+	return r.Quo(r, big.NewRat(100*l.Frequency.PeriodsPerYear(), 1))
+}
+
+// Schedule returns the amortization schedule of the loan. The interest of
+// every period is rounded with the rounding mode of opts, and so are the
+// payments of annuity loans and the repayments of linear loans; the final
+// payment repays whatever balance is left.
+func (l *Loan) Schedule(opts ...Option) (*Schedule, error) {
+	o := newOptions(opts)
+
+	if l.Principal == nil || l.Principal.Currency() == nil || !l.Principal.IsPositive() {
+		return nil, fmt.Errorf("%w: the principal must be positive", ErrInvalidLoan)
+	}
+	if l.AnnualRate == nil || l.AnnualRate.Sign() < 0 {
+		return nil, fmt.Errorf("%w: the rate must not be negative", ErrInvalidLoan)
+	}
+	if l.Periods < 1 {
+		return nil, fmt.Errorf("%w: %d periods", ErrInvalidLoan, l.Periods)
+	}
+	if l.Frequency.PeriodsPerYear() == 0 {
+		return nil, fmt.Errorf("%w: unknown frequency %d", ErrInvalidLoan, l.Frequency)
+	}
+
+	rate := l.periodicRate()
+
+	// the rounded payment of annuity loans, or repayment of linear loans
+	var fixed *money.Money
+	var err error
+	switch l.Method {
+	case Annuity:
+		fixed, err = l.Principal.MultiplyRat(annuityFactor(rate, l.Periods), o.RoundingMode)
+	case Linear:
+		fixed, err = l.Principal.MultiplyRat(big.NewRat(1, int64(l.Periods)), o.RoundingMode)
+	default:
+		return nil, fmt.Errorf("%w: unknown method %d", ErrInvalidLoan, l.Method)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	zero := money.New(0, l.Principal.Currency().Code)
+	s := &Schedule{Payments: make([]Payment, l.Periods), TotalPayment: zero, TotalInterest: zero}
+	balance := l.Principal
+	for i := range s.Payments {
+		interest, err := balance.MultiplyRat(rate, o.RoundingMode)
+		if err != nil {
+			return nil, err
+		}
+
+		principal := fixed
+		if l.Method == Annuity {
+			if principal, err = fixed.Subtract(interest); err != nil {
+				return nil, err
+			}
+		}
+		if i == l.Periods-1 {
+			principal = balance
+		} else if gt, err := principal.GreaterThan(balance); err != nil {
+			return nil, err
+		} else if gt {
+			principal = balance
+		}
+
+		payment, err := interest.Add(principal)
+		if err != nil {
+			return nil, err
+		}
+		if balance, err = balance.Subtract(principal); err != nil {
+			return nil, err
+		}
+
+		s.Payments[i] = Payment{Period: i + 1, Payment: payment, Interest: interest, Principal: principal, Balance: balance}
+		if s.TotalPayment, err = s.TotalPayment.Add(payment); err != nil {
+			return nil, err
+		}
+		if s.TotalInterest, err = s.TotalInterest.Add(interest); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// annuityFactor returns the exact fraction of the principal paid every period
+// to repay it in n equal payments at rate r, r(1+r)^n / ((1+r)^n - 1), or 1/n
+// if r is zero.
+func annuityFactor(r *big.Rat, n int) *big.Rat {
+	if r.Sign() == 0 {
+		return big.NewRat(1, int64(n))
+	}
+
+	g := new(big.Rat).Add(big.NewRat(1, 1), r)
+	pow := new(big.Rat).SetFrac(
+		new(big.Int).Exp(g.Num(), big.NewInt(int64(n)), nil),
+		new(big.Int).Exp(g.Denom(), big.NewInt(int64(n)), nil),
+	)
+
+	f := new(big.Rat).Mul(r, pow)
+	return f.Quo(f, pow.Sub(pow, big.NewRat(1, 1)))
+}
@@ -0,0 +1,164 @@
+package finance
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+)
+
+// checkSchedule asserts that s adds up exactly for a loan of principal.
+func checkSchedule(t *testing.T, s *Schedule, principal int64) {
+	t.Helper()
+
+	balance := principal
+	var payments, interest int64
+	for i, p := range s.Payments {
+		if p.Period != i+1 {
+			t.Errorf("Expected period %d got %d", i+1, p.Period)
+		}
+		if p.Interest.Amount()+p.Principal.Amount() != p.Payment.Amount() {
+			t.Errorf("Expected period %d interest %d + principal %d to be payment %d", p.Period,
+				p.Interest.Amount(), p.Principal.Amount(), p.Payment.Amount())
+		}
+		balance -= p.Principal.Amount()
+		if p.Balance.Amount() != balance || balance < 0 {
+			t.Errorf("Expected period %d balance %d got %d", p.Period, balance, p.Balance.Amount())
+		}
+		payments += p.Payment.Amount()
+		interest += p.Interest.Amount()
+	}
+
+	if balance != 0 {
+		t.Errorf("Expected final balance 0 got %d", balance)
+	}
+	if payments != s.TotalPayment.Amount() || interest != s.TotalInterest.Amount() {
+		t.Errorf("Expected totals %d %d got %d %d", payments, interest, s.TotalPayment.Amount(), s.TotalInterest.Amount())
+	}
+}
+
+func TestLoan_Schedule(t *testing.T) {
+	tests := []struct {
+		name      string
+		loan      Loan
+		first     [4]int64 // payment, interest, principal, balance
+		last      [4]int64
+		interests int64
+	}{
+		{
+			name:      "annuity",
+			loan:      Loan{Principal: money.New(2000000, money.EUR), AnnualRate: big.NewRat(11, 2), Periods: 36},
+			first:     [4]int64{60392, 9167, 51225, 1948775},
+			last:      [4]int64{60386, 276, 60110, 0},
+			interests: 174106,
+		},
+		{
+			name:      "linear",
+			loan:      Loan{Principal: money.New(1200000, money.USD), AnnualRate: big.NewRat(6, 1), Periods: 12, Method: Linear},
+			first:     [4]int64{106000, 6000, 100000, 1100000},
+			last:      [4]int64{100500, 500, 100000, 0},
+			interests: 39000,
+		},
+		{
+			name:      "quarterly",
+			loan:      Loan{Principal: money.New(100000, money.GBP), AnnualRate: big.NewRat(12, 1), Periods: 4, Frequency: Quarterly},
+			first:     [4]int64{26903, 3000, 23903, 76097},
+			last:      [4]int64{26902, 784, 26118, 0},
+			interests: 7611,
+		},
+		{
+			name:  "interest free",
+			loan:  Loan{Principal: money.New(100000, money.EUR), AnnualRate: new(big.Rat), Periods: 3},
+			first: [4]int64{33333, 0, 33333, 66667},
+			last:  [4]int64{33334, 0, 33334, 0},
+		},
+		{
+			name:  "interest free linear",
+			loan:  Loan{Principal: money.New(100000, money.EUR), AnnualRate: new(big.Rat), Periods: 3, Method: Linear},
+			first: [4]int64{33333, 0, 33333, 66667},
+			last:  [4]int64{33334, 0, 33334, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := tc.loan.Schedule()
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkSchedule(t, s, tc.loan.Principal.Amount())
+
+			if len(s.Payments) != tc.loan.Periods {
+				t.Fatalf("Expected %d payments got %d", tc.loan.Periods, len(s.Payments))
+			}
+			for _, w := range []struct {
+				p    Payment
+				want [4]int64
+			}{{s.Payments[0], tc.first}, {s.Payments[len(s.Payments)-1], tc.last}} {
+				got := [4]int64{w.p.Payment.Amount(), w.p.Interest.Amount(), w.p.Principal.Amount(), w.p.Balance.Amount()}
+				if got != w.want {
+					t.Errorf("Expected period %d to be %v got %v", w.p.Period, w.want, got)
+				}
+				if w.p.Payment.Currency().Code != tc.loan.Principal.Currency().Code {
+					t.Errorf("Expected %s got %s", tc.loan.Principal.Currency().Code, w.p.Payment.Currency().Code)
+				}
+			}
+			if s.TotalInterest.Amount() != tc.interests {
+				t.Errorf("Expected total interest %d got %d", tc.interests, s.TotalInterest.Amount())
+			}
+		})
+	}
+}
+
+func TestLoan_Schedule_AddsUp(t *testing.T) {
+	rate, err := ParseRate("4.875")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, method := range []Method{Annuity, Linear} {
+		for _, mode := range []money.RoundingMode{money.RoundHalfUp, money.RoundHalfEven, money.RoundUp, money.RoundDown} {
+			for _, f := range []Frequency{Monthly, Quarterly, SemiAnnually, Annually, Weekly, Biweekly} {
+				for _, n := range []int{1, 7, 60, 360} {
+					loan := Loan{Principal: money.New(123457, money.USD), AnnualRate: rate, Periods: n, Frequency: f, Method: method}
+					s, err := loan.Schedule(WithRoundingMode(mode))
+					if err != nil {
+						t.Fatal(err)
+					}
+					checkSchedule(t, s, 123457)
+				}
+			}
+		}
+	}
+}
+
+func TestLoan_Schedule_Errors(t *testing.T) {
+	rate := big.NewRat(5, 1)
+	tests := []struct {
+		name string
+		loan Loan
+	}{
+		{"no principal", Loan{AnnualRate: rate, Periods: 1}},
+		{"zero principal", Loan{Principal: money.New(0, money.EUR), AnnualRate: rate, Periods: 1}},
+		{"no rate", Loan{Principal: money.New(100, money.EUR), Periods: 1}},
+		{"negative rate", Loan{Principal: money.New(100, money.EUR), AnnualRate: big.NewRat(-1, 1), Periods: 1}},
+		{"no periods", Loan{Principal: money.New(100, money.EUR), AnnualRate: rate}},
+		{"unknown frequency", Loan{Principal: money.New(100, money.EUR), AnnualRate: rate, Periods: 1, Frequency: 42}},
+		{"unknown method", Loan{Principal: money.New(100, money.EUR), AnnualRate: rate, Periods: 1, Method: 42}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.loan.Schedule(); !errors.Is(err, ErrInvalidLoan) {
+				t.Errorf("Expected %v got %v", ErrInvalidLoan, err)
+			}
+		})
+	}
+
+	for _, s := range []string{"-1", "five", ""} {
+		if _, err := ParseRate(s); !errors.Is(err, ErrInvalidRate) {
+			t.Errorf("Expected %v for %q got %v", ErrInvalidRate, s, err)
+		}
+	}
+}
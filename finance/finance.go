@@ -0,0 +1,133 @@
+// Package finance calculates loan schedules and interest on [money.Money]
+// with exact rational arithmetic.
+//
+// Rates are exact decimal percentages, parsed with [ParseRate]. An
+// amortization schedule has equal payments (annuity) or equal principal
+// repayments (linear):
+//
+//	rate, _ := finance.ParseRate("5.5")
+//
+//	s, err := (&finance.Loan{
+//		Principal:  money.New(2000000, money.EUR),
+//		AnnualRate: rate,
+//		Periods:    36,
+//		Frequency:  finance.Monthly,
+//		Method:     finance.Annuity,
+//	}).Schedule()
+//
+// Interest accrues between two dates with a [DayCount] convention, see
+// [Accrual]. A [Plan] splits a total into dated installments, and [Prorate]
+// charges a price for a part of its billing period.
+//
+// Every amount is rounded to the minor units of its currency using a
+// [money.RoundingMode], set with [WithRoundingMode]. The schedule always adds
+// up exactly: the principal repayments sum to the principal, and the final
+// payment is adjusted so the balance ends at zero.
+package finance
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Rhymond/go-money"
+)
+
+var (
+	// ErrInvalidRate is returned when a rate is not a non-negative decimal percentage.
+	ErrInvalidRate = errors.New("invalid rate")
+	// ErrInvalidLoan is returned when a loan has no or a non-positive principal,
+	// a negative rate, no periods or an unknown frequency or method.
+	ErrInvalidLoan = errors.New("invalid loan")
+	// ErrInvalidAccrual is returned when an accrual has no principal, no or a
+	// negative rate, an end before its start or an unknown day count convention.
+	ErrInvalidAccrual = errors.New("invalid accrual")
+	// ErrInvalidPlan is returned when an installment plan has no or a
+	// non-positive total, a down payment or fee out of range or of another
+	// currency, no installments or an unknown frequency.
+	ErrInvalidPlan = errors.New("invalid installment plan")
+	// ErrInvalidPeriod is returned when a period ends before it starts, or an
+	// interval or date to prorate is outside its billing period.
+	ErrInvalidPeriod = errors.New("invalid period")
+)
+
+// ParseRate returns an exact decimal percentage, such as "5" or "4.875", as
+// a rational number, e.g. 39/8 for "4.875".
+func ParseRate(percent string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(percent)
+	if !ok || r.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRate, percent)
+	}
+
+	return r, nil
+}
+
+// Frequency is how often payments are made.
+type Frequency int
+
+const (
+	// Monthly payments are made 12 times a year.
+	Monthly Frequency = iota
+	// Quarterly payments are made 4 times a year.
+	Quarterly
+	// SemiAnnually payments are made twice a year.
+	SemiAnnually
+	// Annually payments are made once a year.
+	Annually
+	// Weekly payments are made 52 times a year.
+	Weekly
+	// Biweekly payments are made every two weeks, 26 times a year.
+	Biweekly
+)
+
+var periodsPerYear = [...]int64{
+	Monthly:      12,
+	Quarterly:    4,
+	SemiAnnually: 2,
+	Annually:     1,
+	Weekly:       52,
+	Biweekly:     26,
+}
+
+// PeriodsPerYear returns the number of payments a year, or 0 for an unknown Frequency.
+func (f Frequency) PeriodsPerYear() int64 {
+	if f < 0 || int(f) >= len(periodsPerYear) {
+		return 0
+	}
+
+	return periodsPerYear[f]
+}
+
+// Option applies a modification to [Options] and returns it.
+type Option func(o *Options) *Options
+
+// WithRoundingMode sets how amounts are rounded to the minor unit of their currency.
+func WithRoundingMode(mode money.RoundingMode) Option {
+	return func(o *Options) *Options {
+		o.RoundingMode = mode
+		return o
+	}
+}
+
+// Options configures the calculations of the package.
+type Options struct {
+	RoundingMode money.RoundingMode
+}
+
+// DefaultOptions returns [Options] with
+//
+// RoundingMode=money.RoundHalfUp.
+func DefaultOptions() *Options {
+	return &Options{
+		RoundingMode: money.RoundHalfUp,
+	}
+}
+
+func newOptions(opts []Option) *Options {
+	opt := DefaultOptions()
+	for _, o := range opts {
+		opt = o(opt)
+	}
+
+	return opt
+}
@@ -0,0 +1,146 @@
+package finance
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+// Remainder tells which installment of a plan absorbs the pennies left over
+// after splitting it into equal installments.
+type Remainder int
+
+const (
+	// RemainderFirst adds the leftover pennies to the first installment.
+	RemainderFirst Remainder = iota
+	// RemainderLast adds the leftover pennies to the last installment.
+	RemainderLast
+)
+
+// Plan is a total paid in installments, e.g. 1,000.00 in 3 monthly installments.
+type Plan struct {
+	Total *money.Money
+	// DownPayment is an optional part of Total paid on Start, before the installments.
+	DownPayment *money.Money
+	// Fee is an optional charge on top of Total, spread across the installments.
+	Fee *money.Money
+	// Count is the number of installments, not counting the down payment.
+	Count int
+	// Start is the due date of the first installment, or of the down payment if
+	// there is one, and then the first installment is due a period later.
+	Start     time.Time
+	Frequency Frequency
+	Remainder Remainder
+}
+
+// Installment is a payment of a plan. Amount includes Fee.
+type Installment struct {
+	// Number is 0 for the down payment, and starts at 1 for the installments.
+	Number int
+	Due    time.Time
+	Amount *money.Money
+	Fee    *money.Money
+}
+
+// Installments returns the down payment, if the plan has one, and the
+// installments of the plan with their due dates. What is left of Total after
+// the down payment, and the fee, are split into equal installments with
+// [money.Money.SplitWith], the remainder going to the first or last one, so
+// the amounts always sum to Total plus Fee.
+//
+// Installments are due every period of Frequency; monthly installments due on
+// a day a month doesn't have are due on the last day of that month.
+func (p *Plan) Installments() ([]Installment, error) {
+	if p.Total == nil || p.Total.Currency() == nil || !p.Total.IsPositive() {
+		return nil, fmt.Errorf("%w: the total must be positive", ErrInvalidPlan)
+	}
+	if p.Count < 1 {
+		return nil, fmt.Errorf("%w: %d installments", ErrInvalidPlan, p.Count)
+	}
+	if p.Frequency.PeriodsPerYear() == 0 {
+		return nil, fmt.Errorf("%w: unknown frequency %d", ErrInvalidPlan, p.Frequency)
+	}
+
+	var leftover money.Leftover
+	switch p.Remainder {
+	case RemainderFirst:
+		leftover = money.LeftoverToIndex(0)
+	case RemainderLast:
+		leftover = money.LeftoverToLast
+	default:
+		return nil, fmt.Errorf("%w: unknown remainder %d", ErrInvalidPlan, p.Remainder)
+	}
+
+	code := p.Total.Currency().Code
+	zero := money.New(0, code)
+	down, fee := zero, zero
+	if p.DownPayment != nil {
+		down = p.DownPayment
+		if !down.SameCurrency(p.Total) {
+			return nil, fmt.Errorf("%w: down payment: %v", ErrInvalidPlan, money.ErrCurrencyMismatch)
+		}
+		if gt, err := down.GreaterThan(p.Total); err != nil || gt || down.IsNegative() {
+			return nil, fmt.Errorf("%w: down payment %s out of range", ErrInvalidPlan, down.Display())
+		}
+	}
+	if p.Fee != nil {
+		fee = p.Fee
+		if !fee.SameCurrency(p.Total) {
+			return nil, fmt.Errorf("%w: fee: %v", ErrInvalidPlan, money.ErrCurrencyMismatch)
+		}
+		if fee.IsNegative() {
+			return nil, fmt.Errorf("%w: negative fee %s", ErrInvalidPlan, fee.Display())
+		}
+	}
+
+	rest, err := p.Total.Subtract(down)
+	if err != nil {
+		return nil, err
+	}
+	amounts, err := rest.SplitWith(p.Count, leftover)
+	if err != nil {
+		return nil, err
+	}
+	fees, err := fee.SplitWith(p.Count, leftover)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]Installment, 0, p.Count+1)
+	period := 0
+	if p.DownPayment != nil {
+		res = append(res, Installment{Number: 0, Due: p.Start, Amount: down, Fee: zero})
+		period++
+	}
+	for i := range amounts {
+		amount, err := amounts[i].Add(fees[i])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, Installment{Number: i + 1, Due: p.Frequency.due(p.Start, period+i), Amount: amount, Fee: fees[i]})
+	}
+
+	return res, nil
+}
+
+// due returns the date n periods of f after start. Months are added to the
+// date of start, and days a month doesn't have are moved back to its last day.
+func (f Frequency) due(start time.Time, n int) time.Time {
+	switch f {
+	case Weekly:
+		return start.AddDate(0, 0, 7*n)
+	case Biweekly:
+		return start.AddDate(0, 0, 14*n)
+	}
+
+	months := int(12/f.PeriodsPerYear()) * n
+	y, m, d := start.Date()
+	// the day before the first of the month after the due month is its last day
+	last := time.Date(y, m+time.Month(months)+1, 0, 0, 0, 0, 0, start.Location()).Day()
+	if d > last {
+		d = last
+	}
+
+	return time.Date(y, m+time.Month(months), d, start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+}
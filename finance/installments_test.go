@@ -0,0 +1,137 @@
+package finance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+func TestPlan_Installments(t *testing.T) {
+	tests := []struct {
+		name    string
+		plan    Plan
+		numbers []int
+		due     []string
+		amounts []int64
+		fees    []int64
+	}{
+		{
+			name:    "three monthly",
+			plan:    Plan{Total: money.New(100000, money.EUR), Count: 3, Start: day("2024-01-31")},
+			numbers: []int{1, 2, 3},
+			due:     []string{"2024-01-31", "2024-02-29", "2024-03-31"},
+			amounts: []int64{33334, 33333, 33333},
+			fees:    []int64{0, 0, 0},
+		},
+		{
+			name:    "remainder last",
+			plan:    Plan{Total: money.New(100000, money.EUR), Count: 3, Start: day("2024-01-31"), Remainder: RemainderLast},
+			numbers: []int{1, 2, 3},
+			due:     []string{"2024-01-31", "2024-02-29", "2024-03-31"},
+			amounts: []int64{33333, 33333, 33334},
+			fees:    []int64{0, 0, 0},
+		},
+		{
+			name: "down payment and fee",
+			plan: Plan{Total: money.New(120000, money.USD), DownPayment: money.New(20000, money.USD), Fee: money.New(1000, money.USD),
+				Count: 3, Start: day("2024-11-30"), Frequency: Quarterly},
+			numbers: []int{0, 1, 2, 3},
+			due:     []string{"2024-11-30", "2025-02-28", "2025-05-30", "2025-08-30"},
+			amounts: []int64{20000, 33334 + 334, 33333 + 333, 33333 + 333},
+			fees:    []int64{0, 334, 333, 333},
+		},
+		{
+			name:    "biweekly",
+			plan:    Plan{Total: money.New(1000, money.GBP), Fee: money.New(2, money.GBP), Count: 4, Start: day("2024-12-20"), Frequency: Biweekly, Remainder: RemainderLast},
+			numbers: []int{1, 2, 3, 4},
+			due:     []string{"2024-12-20", "2025-01-03", "2025-01-17", "2025-01-31"},
+			amounts: []int64{250, 250, 250, 252},
+			fees:    []int64{0, 0, 0, 2},
+		},
+		{
+			name:    "all down",
+			plan:    Plan{Total: money.New(5000, money.EUR), DownPayment: money.New(5000, money.EUR), Count: 2, Start: day("2024-02-29"), Frequency: Annually},
+			numbers: []int{0, 1, 2},
+			due:     []string{"2024-02-29", "2025-02-28", "2026-02-28"},
+			amounts: []int64{5000, 0, 0},
+			fees:    []int64{0, 0, 0},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			is, err := tc.plan.Installments()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(is) != len(tc.amounts) {
+				t.Fatalf("Expected %d installments got %d", len(tc.amounts), len(is))
+			}
+
+			var sum int64
+			for i, in := range is {
+				if in.Number != tc.numbers[i] || in.Due.Format(dateFormat) != tc.due[i] ||
+					in.Amount.Amount() != tc.amounts[i] || in.Fee.Amount() != tc.fees[i] {
+					t.Errorf("Expected installment %d to be %d %s %d %d got %d %s %d %d", i, tc.numbers[i], tc.due[i], tc.amounts[i], tc.fees[i],
+						in.Number, in.Due.Format(dateFormat), in.Amount.Amount(), in.Fee.Amount())
+				}
+				if in.Amount.Currency().Code != tc.plan.Total.Currency().Code {
+					t.Errorf("Expected %s got %s", tc.plan.Total.Currency().Code, in.Amount.Currency().Code)
+				}
+				sum += in.Amount.Amount()
+			}
+
+			want := tc.plan.Total.Amount()
+			if tc.plan.Fee != nil {
+				want += tc.plan.Fee.Amount()
+			}
+			if sum != want {
+				t.Errorf("Expected installments to sum to %d got %d", want, sum)
+			}
+		})
+	}
+}
+
+func TestFrequency_due(t *testing.T) {
+	start := time.Date(2024, 1, 31, 10, 30, 0, 0, time.UTC)
+	want := []string{"2024-01-31", "2024-02-29", "2024-03-31", "2024-04-30", "2025-02-28"}
+	for i, n := range []int{0, 1, 2, 3, 13} {
+		got := Monthly.due(start, n)
+		if got.Format(dateFormat) != want[i] || got.Hour() != 10 || got.Minute() != 30 {
+			t.Errorf("Expected %s got %s", want[i], got)
+		}
+	}
+
+	if got := Weekly.due(start, 2); got.Format(dateFormat) != "2024-02-14" {
+		t.Errorf("Expected 2024-02-14 got %s", got)
+	}
+}
+
+func TestPlan_Installments_Errors(t *testing.T) {
+	total := money.New(1000, money.EUR)
+	tests := []struct {
+		name string
+		plan Plan
+	}{
+		{"no total", Plan{Count: 1}},
+		{"zero total", Plan{Total: money.New(0, money.EUR), Count: 1}},
+		{"no installments", Plan{Total: total}},
+		{"unknown frequency", Plan{Total: total, Count: 1, Frequency: 42}},
+		{"unknown remainder", Plan{Total: total, Count: 1, Remainder: 42}},
+		{"down payment over total", Plan{Total: total, Count: 1, DownPayment: money.New(1001, money.EUR)}},
+		{"negative down payment", Plan{Total: total, Count: 1, DownPayment: money.New(-1, money.EUR)}},
+		{"down payment currency", Plan{Total: total, Count: 1, DownPayment: money.New(1, money.USD)}},
+		{"negative fee", Plan{Total: total, Count: 1, Fee: money.New(-1, money.EUR)}},
+		{"fee currency", Plan{Total: total, Count: 1, Fee: money.New(1, money.USD)}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.plan.Installments(); !errors.Is(err, ErrInvalidPlan) {
+				t.Errorf("Expected %v got %v", ErrInvalidPlan, err)
+			}
+		})
+	}
+}
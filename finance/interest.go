@@ -0,0 +1,246 @@
+package finance
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+// DayCount is a day count convention, which tells what fraction of a year
+// lies between two dates.
+type DayCount int
+
+const (
+	// Act360 counts the actual days over a year of 360 days.
+	Act360 DayCount = iota
+	// Act365Fixed counts the actual days over a year of 365 days.
+	Act365Fixed
+	// ActActISDA counts the actual days in each calendar year over the days
+	// of that year, 365 or 366.
+	ActActISDA
+	// Thirty360US counts months of 30 days over a year of 360 days, with the
+	// end of month rules of the US (bond basis), which treat the last day of
+	// February as the 30th.
+	Thirty360US
+	// Thirty360E counts months of 30 days over a year of 360 days, moving the
+	// 31st of a month to the 30th (Eurobond basis).
+	Thirty360E
+)
+
+var dayCountNames = [...]string{
+	Act360:      "ACT/360",
+	Act365Fixed: "ACT/365F",
+	ActActISDA:  "ACT/ACT ISDA",
+	Thirty360US: "30/360 US",
+	Thirty360E:  "30E/360",
+}
+
+func (dc DayCount) String() string {
+	if dc < 0 || int(dc) >= len(dayCountNames) {
+		return fmt.Sprintf("DayCount(%d)", int(dc))
+	}
+	return dayCountNames[dc]
+}
+
+// YearFraction returns the exact fraction of a year from start to end. Only
+// the dates of start and end count, not their times of day.
+func (dc DayCount) YearFraction(start, end time.Time) (*big.Rat, error) {
+	start, end = date(start), date(end)
+	if end.Before(start) {
+		return nil, fmt.Errorf("%w: end %s before start %s", ErrInvalidAccrual, end.Format(dateFormat), start.Format(dateFormat))
+	}
+
+	switch dc {
+	case Act360:
+		return big.NewRat(days(start, end), 360), nil
+	case Act365Fixed:
+		return big.NewRat(days(start, end), 365), nil
+	case ActActISDA:
+		f := new(big.Rat)
+		for y := start.Year(); y <= end.Year(); y++ {
+			from, to := time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(y+1, 1, 1, 0, 0, 0, 0, time.UTC)
+			if from.Before(start) {
+				from = start
+			}
+			if to.After(end) {
+				to = end
+			}
+			f.Add(f, big.NewRat(days(from, to), daysInYear(y)))
+		}
+		return f, nil
+	case Thirty360US, Thirty360E:
+		y1, m1, d1 := start.Date()
+		y2, m2, d2 := end.Date()
+		if dc == Thirty360US {
+			if lastOfFebruary(start) && lastOfFebruary(end) {
+				d2 = 30
+			}
+			if lastOfFebruary(start) {
+				d1 = 30
+			}
+			if d2 == 31 && d1 >= 30 {
+				d2 = 30
+			}
+			if d1 == 31 {
+				d1 = 30
+			}
+		} else {
+			if d1 == 31 {
+				d1 = 30
+			}
+			if d2 == 31 {
+				d2 = 30
+			}
+		}
+		n := 360*(y2-y1) + 30*(int(m2)-int(m1)) + d2 - d1
+		return big.NewRat(int64(n), 360), nil
+	default:
+		return nil, fmt.Errorf("%w: unknown day count %s", ErrInvalidAccrual, dc)
+	}
+}
+
+// Accrual is interest accruing on a balance from Start to End.
+type Accrual struct {
+	Principal *money.Money
+	// AnnualRate is the exact annual interest rate as a percentage, e.g. 5.5 for 5.5%.
+	AnnualRate *big.Rat
+	Start, End time.Time
+	DayCount   DayCount
+	// Compound interest is added to the balance at the end of every period of
+	// Compounding from Start, and accrues simple interest within a period.
+	// Otherwise, interest is simple interest on Principal.
+	Compound    bool
+	Compounding Frequency
+}
+
+// DailyInterest is the interest accrued on a day.
+type DailyInterest struct {
+	// Date is the day the interest accrued up to, from the day before.
+	Date     time.Time
+	Interest *money.Money
+}
+
+// Interest returns the interest accrued from Start to End, rounded with the
+// rounding mode of opts.
+func (a *Accrual) Interest(opts ...Option) (*money.Money, error) {
+	o := newOptions(opts)
+
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+
+	r, err := a.interest(a.End)
+	if err != nil {
+		return nil, err
+	}
+
+	return money.NewFromRat(r, a.Principal.Currency().Code, o.RoundingMode)
+}
+
+// Daily returns the interest accrued on every day from Start to End, one entry
+// per day after Start up to End. The interest of a day is the difference of
+// the rounded interest accrued up to it and up to the day before, so the days
+// always sum to Interest.
+func (a *Accrual) Daily(opts ...Option) ([]DailyInterest, error) {
+	o := newOptions(opts)
+
+	if err := a.validate(); err != nil {
+		return nil, err
+	}
+
+	code := a.Principal.Currency().Code
+	start, end := date(a.Start), date(a.End)
+	res := make([]DailyInterest, 0, days(start, end))
+	prev := money.New(0, code)
+	for d := start.AddDate(0, 0, 1); !d.After(end); d = d.AddDate(0, 0, 1) {
+		r, err := a.interest(d)
+		if err != nil {
+			return nil, err
+		}
+		total, err := money.NewFromRat(r, code, o.RoundingMode)
+		if err != nil {
+			return nil, err
+		}
+
+		day, err := total.Subtract(prev)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, DailyInterest{Date: d, Interest: day})
+		prev = total
+	}
+
+	return res, nil
+}
+
+func (a *Accrual) validate() error {
+	if a.Principal == nil || a.Principal.Currency() == nil {
+		return fmt.Errorf("%w: no principal", ErrInvalidAccrual)
+	}
+	if a.AnnualRate == nil || a.AnnualRate.Sign() < 0 {
+		return fmt.Errorf("%w: the rate must not be negative", ErrInvalidAccrual)
+	}
+	if date(a.End).Before(date(a.Start)) {
+		return fmt.Errorf("%w: end %s before start %s", ErrInvalidAccrual, a.End.Format(dateFormat), a.Start.Format(dateFormat))
+	}
+	if a.Compound && a.Compounding.PeriodsPerYear() == 0 {
+		return fmt.Errorf("%w: unknown compounding frequency %d", ErrInvalidAccrual, a.Compounding)
+	}
+
+	return nil
+}
+
+// interest returns the exact interest accrued from Start to end in major units.
+func (a *Accrual) interest(end time.Time) (*big.Rat, error) {
+	t, err := a.DayCount.YearFraction(a.Start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	rate := new(big.Rat).Quo(a.AnnualRate, big.NewRat(100, 1))
+	f := new(big.Rat).Mul(rate, t)
+	if a.Compound {
+		// (1 + r/m)^n (1 + r/m s) - 1 for n whole periods and a stub s of one
+		m := big.NewRat(a.Compounding.PeriodsPerYear(), 1)
+		periods := new(big.Rat).Mul(t, m)
+		n := new(big.Int).Quo(periods.Num(), periods.Denom())
+		stub := periods.Sub(periods, new(big.Rat).SetInt(n))
+
+		g := new(big.Rat).Quo(rate, m)
+		f = new(big.Rat).Mul(g, stub)
+		f.Add(f, big.NewRat(1, 1))
+
+		g.Add(g, big.NewRat(1, 1))
+		pow := new(big.Rat).SetFrac(new(big.Int).Exp(g.Num(), n, nil), new(big.Int).Exp(g.Denom(), n, nil))
+		f.Mul(f, pow)
+		f.Sub(f, big.NewRat(1, 1))
+	}
+
+	return f.Mul(f, a.Principal.Rat()), nil
+}
+
+const dateFormat = "2006-01-02"
+
+// date returns the date of t at midnight UTC.
+func date(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// days returns the number of days from the date start to the date end.
+func days(start, end time.Time) int64 {
+	return int64(end.Sub(start) / (24 * time.Hour))
+}
+
+func daysInYear(y int) int64 {
+	if y%4 == 0 && (y%100 != 0 || y%400 == 0) {
+		return 366
+	}
+	return 365
+}
+
+func lastOfFebruary(t time.Time) bool {
+	return t.Month() == time.February && t.AddDate(0, 0, 1).Month() == time.March
+}
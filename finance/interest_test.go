@@ -0,0 +1,211 @@
+package finance
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+func day(s string) time.Time {
+	t, err := time.Parse(dateFormat, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestDayCount_YearFraction(t *testing.T) {
+	tests := []struct {
+		dc         DayCount
+		start, end string
+		want       *big.Rat
+	}{
+		{Act360, "2024-01-15", "2024-04-15", big.NewRat(91, 360)},
+		{Act365Fixed, "2024-01-15", "2024-04-15", big.NewRat(91, 365)},
+		{Act365Fixed, "2024-01-15", "2024-01-15", new(big.Rat)},
+		{ActActISDA, "2023-12-15", "2024-01-15", new(big.Rat).Add(big.NewRat(17, 365), big.NewRat(14, 366))},
+		{ActActISDA, "2024-01-01", "2025-01-01", big.NewRat(1, 1)},
+		{ActActISDA, "2023-07-01", "2025-07-01", new(big.Rat).Add(big.NewRat(184+181, 365), big.NewRat(366, 366))},
+		{Thirty360US, "2024-01-15", "2024-03-31", big.NewRat(76, 360)},
+		{Thirty360US, "2024-01-31", "2024-03-31", big.NewRat(60, 360)},
+		{Thirty360US, "2024-02-29", "2024-03-31", big.NewRat(30, 360)},
+		{Thirty360US, "2023-02-28", "2024-02-29", big.NewRat(1, 1)},
+		{Thirty360US, "2024-02-28", "2024-03-01", big.NewRat(3, 360)},
+		{Thirty360E, "2024-01-15", "2024-03-31", big.NewRat(75, 360)},
+		{Thirty360E, "2024-02-29", "2024-03-31", big.NewRat(31, 360)},
+		{Thirty360E, "2024-01-31", "2024-03-31", big.NewRat(60, 360)},
+	}
+
+	for _, tc := range tests {
+		got, err := tc.dc.YearFraction(day(tc.start), day(tc.end))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Cmp(tc.want) != 0 {
+			t.Errorf("Expected %s from %s to %s to be %s got %s", tc.dc, tc.start, tc.end, tc.want, got)
+		}
+	}
+
+	// the time of day doesn't count
+	start := time.Date(2024, 1, 15, 23, 0, 0, 0, time.FixedZone("", -5*3600))
+	if got, _ := Act360.YearFraction(start, day("2024-01-16")); got.Cmp(big.NewRat(1, 360)) != 0 {
+		t.Errorf("Expected 1/360 got %s", got)
+	}
+
+	if _, err := Act360.YearFraction(day("2024-01-02"), day("2024-01-01")); !errors.Is(err, ErrInvalidAccrual) {
+		t.Errorf("Expected %v got %v", ErrInvalidAccrual, err)
+	}
+	if _, err := DayCount(42).YearFraction(day("2024-01-01"), day("2024-01-02")); !errors.Is(err, ErrInvalidAccrual) {
+		t.Errorf("Expected %v got %v", ErrInvalidAccrual, err)
+	}
+	if s := DayCount(42).String(); s != "DayCount(42)" {
+		t.Errorf("Expected DayCount(42) got %s", s)
+	}
+}
+
+func TestAccrual_Interest(t *testing.T) {
+	tests := []struct {
+		name string
+		a    Accrual
+		want int64
+	}{
+		{
+			name: "simple ACT/360",
+			a:    Accrual{Principal: money.New(100000000, money.USD), AnnualRate: big.NewRat(5, 1), Start: day("2024-01-15"), End: day("2024-04-15")},
+			want: 1263889,
+		},
+		{
+			name: "simple ACT/365F",
+			a: Accrual{Principal: money.New(100000000, money.USD), AnnualRate: big.NewRat(5, 1), Start: day("2024-01-15"), End: day("2024-04-15"),
+				DayCount: Act365Fixed},
+			want: 1246575,
+		},
+		{
+			name: "negative balance",
+			a:    Accrual{Principal: money.New(-100000000, money.USD), AnnualRate: big.NewRat(5, 1), Start: day("2024-01-15"), End: day("2024-04-15")},
+			want: -1263889,
+		},
+		{
+			name: "compound monthly",
+			a: Accrual{Principal: money.New(100000, money.EUR), AnnualRate: big.NewRat(12, 1), Start: day("2023-01-01"), End: day("2024-01-01"),
+				DayCount: Act365Fixed, Compound: true},
+			want: 12683,
+		},
+		{
+			name: "compound with a stub",
+			a: Accrual{Principal: money.New(100000, money.EUR), AnnualRate: big.NewRat(12, 1), Start: day("2024-01-01"), End: day("2024-02-16"),
+				DayCount: Thirty360E, Compound: true},
+			want: 1505,
+		},
+		{
+			name: "compound annually",
+			a: Accrual{Principal: money.New(100000, money.EUR), AnnualRate: big.NewRat(10, 1), Start: day("2022-01-01"), End: day("2024-07-01"),
+				DayCount: Thirty360US, Compound: true, Compounding: Annually},
+			want: 27050,
+		},
+		{
+			name: "no time",
+			a:    Accrual{Principal: money.New(100000, money.EUR), AnnualRate: big.NewRat(10, 1), Start: day("2022-01-01"), End: day("2022-01-01")},
+			want: 0,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := tc.a.Interest()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Amount() != tc.want || got.Currency().Code != tc.a.Principal.Currency().Code {
+				t.Errorf("Expected %d got %d %s", tc.want, got.Amount(), got.Currency().Code)
+			}
+		})
+	}
+
+	a := Accrual{Principal: money.New(100000000, money.USD), AnnualRate: big.NewRat(5, 1), Start: day("2024-01-15"), End: day("2024-04-15")}
+	if got, _ := a.Interest(WithRoundingMode(money.RoundDown)); got.Amount() != 1263888 {
+		t.Errorf("Expected 1263888 got %d", got.Amount())
+	}
+}
+
+func TestAccrual_Daily(t *testing.T) {
+	for dc := Act360; dc <= Thirty360E; dc++ {
+		for _, compound := range []bool{false, true} {
+			a := Accrual{
+				Principal:  money.New(123456789, money.EUR),
+				AnnualRate: big.NewRat(37, 10),
+				Start:      day("2023-12-20"),
+				End:        day("2024-03-10"),
+				DayCount:   dc,
+				Compound:   compound,
+			}
+
+			total, err := a.Interest()
+			if err != nil {
+				t.Fatal(err)
+			}
+			daily, err := a.Daily()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(daily) != 81 {
+				t.Fatalf("Expected 81 days got %d", len(daily))
+			}
+			var sum int64
+			for _, d := range daily {
+				sum += d.Interest.Amount()
+			}
+			if sum != total.Amount() {
+				t.Errorf("Expected %s days to sum to %d got %d", dc, total.Amount(), sum)
+			}
+			if !daily[0].Date.Equal(day("2023-12-21")) || !daily[80].Date.Equal(day("2024-03-10")) {
+				t.Errorf("Expected days from 2023-12-21 to 2024-03-10 got %s to %s", daily[0].Date, daily[80].Date)
+			}
+		}
+	}
+
+	// 30/360 counts no day for the 31st and two days for the 1st of March after February 29th
+	a := Accrual{Principal: money.New(3600000, money.USD), AnnualRate: big.NewRat(10, 1), Start: day("2024-01-30"), End: day("2024-03-01"),
+		DayCount: Thirty360US}
+	daily, err := a.Daily()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := daily[0].Interest.Amount(); got != 0 {
+		t.Errorf("Expected nothing on January 31st got %d", got)
+	}
+	if got := daily[len(daily)-1].Interest.Amount(); got != 2000 {
+		t.Errorf("Expected 2000 on March 1st got %d", got)
+	}
+}
+
+func TestAccrual_Errors(t *testing.T) {
+	p := money.New(100, money.EUR)
+	rate := big.NewRat(1, 1)
+	tests := []struct {
+		name string
+		a    Accrual
+	}{
+		{"no principal", Accrual{AnnualRate: rate}},
+		{"no rate", Accrual{Principal: p}},
+		{"negative rate", Accrual{Principal: p, AnnualRate: big.NewRat(-1, 1)}},
+		{"end before start", Accrual{Principal: p, AnnualRate: rate, Start: day("2024-01-02"), End: day("2024-01-01")}},
+		{"unknown day count", Accrual{Principal: p, AnnualRate: rate, DayCount: 42}},
+		{"unknown compounding", Accrual{Principal: p, AnnualRate: rate, Compound: true, Compounding: 42}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.a.Interest(); !errors.Is(err, ErrInvalidAccrual) {
+				t.Errorf("Expected %v got %v", ErrInvalidAccrual, err)
+			}
+			if _, err := tc.a.Daily(); tc.name != "unknown day count" && !errors.Is(err, ErrInvalidAccrual) {
+				t.Errorf("Expected %v got %v", ErrInvalidAccrual, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package finance
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+// Period is the days from the date of Start up to, but not including, the
+// date of End, e.g. a billing month from 2024-01-01 to 2024-02-01.
+type Period struct {
+	Start, End time.Time
+}
+
+// Days returns the number of days of the period.
+func (p Period) Days() int64 {
+	return days(date(p.Start), date(p.End))
+}
+
+func (p Period) String() string {
+	return date(p.Start).Format(dateFormat) + "/" + date(p.End).Format(dateFormat)
+}
+
+// contains reports whether q is within p.
+func (p Period) contains(q Period) bool {
+	return !date(q.Start).Before(date(p.Start)) && !date(q.End).After(date(p.End)) && !date(q.End).Before(date(q.Start))
+}
+
+// Segment is the part of a price charged for a part of its billing period.
+type Segment struct {
+	Period Period
+	Amount *money.Money
+}
+
+// Adjustment is the proration of a change of price in the middle of a billing
+// period, such as an upgrade or a downgrade. Credit is the negative amount of
+// the old price for the rest of the period, Charge the amount of the new price
+// for it, and Net their sum.
+type Adjustment struct {
+	Credit *money.Money
+	Charge *money.Money
+	Net    *money.Money
+}
+
+// Prorate returns the part of price charged for a billing period that is
+// charged for usage within it, price times the days of usage over the days of
+// period, rounded with the rounding mode of opts.
+func Prorate(price *money.Money, period, usage Period, opts ...Option) (*money.Money, error) {
+	o := newOptions(opts)
+
+	if err := validatePeriod(price, period); err != nil {
+		return nil, err
+	}
+	if !period.contains(usage) {
+		return nil, fmt.Errorf("%w: usage %s outside %s", ErrInvalidPeriod, usage, period)
+	}
+
+	return price.MultiplyRat(big.NewRat(usage.Days(), period.Days()), o.RoundingMode)
+}
+
+// ProrateSegments splits period at the dates at, in increasing order, and
+// returns the part of price charged for a billing period that is charged for
+// each segment. Segments are rounded with the rounding mode of opts as the
+// difference of the rounded amounts up to their end and up to their start, so
+// they always sum to price.
+func ProrateSegments(price *money.Money, period Period, at []time.Time, opts ...Option) ([]Segment, error) {
+	o := newOptions(opts)
+
+	if err := validatePeriod(price, period); err != nil {
+		return nil, err
+	}
+
+	bounds := make([]time.Time, 0, len(at)+2)
+	bounds = append(bounds, date(period.Start))
+	for _, t := range at {
+		t = date(t)
+		if !t.After(bounds[len(bounds)-1]) || !t.Before(date(period.End)) {
+			return nil, fmt.Errorf("%w: %s is not after the previous date and within %s", ErrInvalidPeriod, t.Format(dateFormat), period)
+		}
+		bounds = append(bounds, t)
+	}
+	bounds = append(bounds, date(period.End))
+
+	total := period.Days()
+	res := make([]Segment, len(bounds)-1)
+	prev := money.New(0, price.Currency().Code)
+	for i := range res {
+		upTo, err := price.MultiplyRat(big.NewRat(days(bounds[0], bounds[i+1]), total), o.RoundingMode)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := upTo.Subtract(prev)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = Segment{Period: Period{Start: bounds[i], End: bounds[i+1]}, Amount: amount}
+		prev = upTo
+	}
+
+	return res, nil
+}
+
+// Change returns the Adjustment for changing from the price from to the price
+// to on the date at within period, which were both charged for the whole of it.
+// Prices are prorated for the days from at to the end of period, as by Prorate.
+func Change(from, to *money.Money, period Period, at time.Time, opts ...Option) (*Adjustment, error) {
+	if err := validatePeriod(from, period); err != nil {
+		return nil, err
+	}
+	if err := validatePeriod(to, period); err != nil {
+		return nil, err
+	}
+	if !from.SameCurrency(to) {
+		return nil, money.ErrCurrencyMismatch
+	}
+
+	rest := Period{Start: at, End: period.End}
+	unused, err := Prorate(from, period, rest, opts...)
+	if err != nil {
+		return nil, err
+	}
+	charge, err := Prorate(to, period, rest, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	credit := unused.Multiply(-1)
+	net, err := charge.Add(credit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Adjustment{Credit: credit, Charge: charge, Net: net}, nil
+}
+
+func validatePeriod(price *money.Money, period Period) error {
+	if price == nil || price.Currency() == nil {
+		return fmt.Errorf("%w: no price", ErrInvalidPeriod)
+	}
+	if period.Days() < 1 {
+		return fmt.Errorf("%w: %s has no days", ErrInvalidPeriod, period)
+	}
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package finance
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+func TestProrate(t *testing.T) {
+	january := Period{Start: day("2024-01-01"), End: day("2024-02-01")}
+
+	tests := []struct {
+		usage Period
+		mode  money.RoundingMode
+		want  int64
+	}{
+		{Period{Start: day("2024-01-10"), End: day("2024-02-01")}, money.RoundHalfUp, 2129},
+		{Period{Start: day("2024-01-10"), End: day("2024-02-01")}, money.RoundUp, 2130},
+		{Period{Start: day("2024-01-01"), End: day("2024-02-01")}, money.RoundHalfUp, 3000},
+		{Period{Start: day("2024-01-15"), End: day("2024-01-15")}, money.RoundHalfUp, 0},
+	}
+
+	for _, tc := range tests {
+		got, err := Prorate(money.New(3000, money.USD), january, tc.usage, WithRoundingMode(tc.mode))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Amount() != tc.want || got.Currency().Code != money.USD {
+			t.Errorf("Expected %s of %s to be %d got %d", tc.usage, january, tc.want, got.Amount())
+		}
+	}
+
+	for _, usage := range []Period{
+		{Start: day("2023-12-31"), End: day("2024-01-10")},
+		{Start: day("2024-01-10"), End: day("2024-02-02")},
+		{Start: day("2024-01-10"), End: day("2024-01-09")},
+	} {
+		if _, err := Prorate(money.New(3000, money.USD), january, usage); !errors.Is(err, ErrInvalidPeriod) {
+			t.Errorf("Expected %v for %s got %v", ErrInvalidPeriod, usage, err)
+		}
+	}
+	if _, err := Prorate(money.New(3000, money.USD), Period{Start: day("2024-01-01"), End: day("2024-01-01")}, january); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("Expected %v got %v", ErrInvalidPeriod, err)
+	}
+	if _, err := Prorate(nil, january, january); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("Expected %v got %v", ErrInvalidPeriod, err)
+	}
+}
+
+func TestProrateSegments(t *testing.T) {
+	february := Period{Start: day("2024-02-01"), End: day("2024-03-01")}
+
+	segments, err := ProrateSegments(money.New(1000, money.EUR), february, []time.Time{day("2024-02-10"), day("2024-02-20")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		period string
+		amount int64
+	}{
+		{"2024-02-01/2024-02-10", 310},
+		{"2024-02-10/2024-02-20", 345},
+		{"2024-02-20/2024-03-01", 345},
+	}
+	if len(segments) != len(want) {
+		t.Fatalf("Expected %d segments got %d", len(want), len(segments))
+	}
+	for i, w := range want {
+		if segments[i].Period.String() != w.period || segments[i].Amount.Amount() != w.amount {
+			t.Errorf("Expected segment %d to be %s %d got %s %d", i, w.period, w.amount, segments[i].Period, segments[i].Amount.Amount())
+		}
+	}
+
+	for _, mode := range []money.RoundingMode{money.RoundHalfUp, money.RoundUp, money.RoundDown, money.RoundHalfEven} {
+		var at []time.Time
+		for d := day("2024-02-02"); d.Before(february.End); d = d.AddDate(0, 0, 1) {
+			at = append(at, d)
+		}
+
+		segments, err := ProrateSegments(money.New(99999, money.EUR), february, at, WithRoundingMode(mode))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var sum int64
+		for _, s := range segments {
+			sum += s.Amount.Amount()
+		}
+		if len(segments) != 29 || sum != 99999 {
+			t.Errorf("Expected 29 segments summing to 99999 got %d summing to %d", len(segments), sum)
+		}
+	}
+
+	whole, err := ProrateSegments(money.New(1000, money.EUR), february, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(whole) != 1 || whole[0].Amount.Amount() != 1000 {
+		t.Errorf("Expected the whole period got %+v", whole)
+	}
+
+	for _, at := range [][]time.Time{
+		{day("2024-02-01")},
+		{day("2024-03-01")},
+		{day("2024-02-20"), day("2024-02-10")},
+		{day("2024-02-10"), day("2024-02-10")},
+	} {
+		if _, err := ProrateSegments(money.New(1000, money.EUR), february, at); !errors.Is(err, ErrInvalidPeriod) {
+			t.Errorf("Expected %v for %v got %v", ErrInvalidPeriod, at, err)
+		}
+	}
+}
+
+func TestChange(t *testing.T) {
+	january := Period{Start: day("2024-01-01"), End: day("2024-02-01")}
+
+	upgrade, err := Change(money.New(1000, money.EUR), money.New(2500, money.EUR), january, day("2024-01-17"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upgrade.Credit.Amount() != -484 || upgrade.Charge.Amount() != 1210 || upgrade.Net.Amount() != 726 {
+		t.Errorf("Expected -484 + 1210 = 726 got %d + %d = %d", upgrade.Credit.Amount(), upgrade.Charge.Amount(), upgrade.Net.Amount())
+	}
+
+	downgrade, err := Change(money.New(2500, money.EUR), money.New(1000, money.EUR), january, day("2024-01-17"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if downgrade.Net.Amount() != -726 {
+		t.Errorf("Expected -726 got %d", downgrade.Net.Amount())
+	}
+
+	if _, err := Change(money.New(1000, money.EUR), money.New(2500, money.USD), january, day("2024-01-17")); !errors.Is(err, money.ErrCurrencyMismatch) {
+		t.Errorf("Expected %v got %v", money.ErrCurrencyMismatch, err)
+	}
+	if _, err := Change(money.New(1000, money.EUR), money.New(2500, money.EUR), january, day("2024-02-17")); !errors.Is(err, ErrInvalidPeriod) {
+		t.Errorf("Expected %v got %v", ErrInvalidPeriod, err)
+	}
+}
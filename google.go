@@ -0,0 +1,94 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+var (
+	// ErrInvalidGoogleMoney happens when a GoogleMoney value is malformed or cannot be represented as Money.
+	ErrInvalidGoogleMoney = errors.New("invalid google money")
+
+	// ErrFractionTooLarge happens when a currency has more fractional digits than GoogleMoney nanos can hold.
+	ErrFractionTooLarge = errors.New("currency fraction too large")
+)
+
+// nanosPerUnit is the number of nanos in a unit of GoogleMoney.
+const nanosPerUnit = 1000000000
+
+// GoogleMoney mirrors the google.type.Money protobuf message, so Money can be
+// converted to and from it without depending on a protobuf runtime.
+//
+// Units holds the whole units of the amount and Nanos the number of nano
+// (10^-9) units. Nanos must be between -999,999,999 and +999,999,999, and must
+// not have the opposite sign of a non-zero Units.
+type GoogleMoney struct {
+	CurrencyCode string
+	Units        int64
+	Nanos        int32
+}
+
+// NewFromGoogleMoney creates and returns new instance of Money from a GoogleMoney.
+// It returns ErrInvalidGoogleMoney if g is malformed or its nanos have more
+// precision than the fraction of its currency.
+func NewFromGoogleMoney(g GoogleMoney) (*Money, error) {
+	if g.CurrencyCode == "" {
+		return nil, fmt.Errorf("%w: empty currency code", ErrInvalidGoogleMoney)
+	}
+	if g.Nanos <= -nanosPerUnit || g.Nanos >= nanosPerUnit {
+		return nil, fmt.Errorf("%w: nanos %d out of range", ErrInvalidGoogleMoney, g.Nanos)
+	}
+	if (g.Units > 0 && g.Nanos < 0) || (g.Units < 0 && g.Nanos > 0) {
+		return nil, fmt.Errorf("%w: units %d and nanos %d have opposite signs", ErrInvalidGoogleMoney, g.Units, g.Nanos)
+	}
+
+	c := newCurrency(g.CurrencyCode).get()
+	if c.Fraction > 9 {
+		return nil, fmt.Errorf("%w: %s has %d fractional digits", ErrFractionTooLarge, c.Code, c.Fraction)
+	}
+
+	scale := int32(pow10(9 - c.Fraction))
+	if g.Nanos%scale != 0 {
+		return nil, fmt.Errorf("%w: nanos %d have more precision than %s allows", ErrInvalidGoogleMoney, g.Nanos, c.Code)
+	}
+
+	amount := new(big.Int).Mul(big.NewInt(g.Units), big.NewInt(pow10(c.Fraction)))
+	amount.Add(amount, big.NewInt(int64(g.Nanos/scale)))
+	if !amount.IsInt64() {
+		return nil, fmt.Errorf("%w: %d units of %s overflow Amount", ErrInvalidGoogleMoney, g.Units, c.Code)
+	}
+
+	return &Money{amount: amount.Int64(), currency: c}, nil
+}
+
+// GoogleMoney returns Money as a GoogleMoney, with Units and Nanos of the same sign.
+// It returns ErrFractionTooLarge for currencies with more than 9 fractional digits,
+// which GoogleMoney cannot represent without rounding, and ErrInvalidGoogleMoney
+// wrapping ErrNoCurrency for the zero Money.
+func (m *Money) GoogleMoney() (GoogleMoney, error) {
+	if m.currency == nil {
+		return GoogleMoney{}, wrapError(ErrInvalidGoogleMoney, ErrNoCurrency)
+	}
+
+	c := m.currency.get()
+	if c.Fraction > 9 {
+		return GoogleMoney{}, fmt.Errorf("%w: %s has %d fractional digits", ErrFractionTooLarge, c.Code, c.Fraction)
+	}
+
+	base := pow10(c.Fraction)
+	return GoogleMoney{
+		CurrencyCode: c.Code,
+		Units:        m.amount / base,
+		Nanos:        int32(m.amount % base * pow10(9-c.Fraction)),
+	}, nil
+}
+
+// pow10 returns 10^n for 0 <= n <= 18.
+func pow10(n int) int64 {
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
+	}
+	return p
+}
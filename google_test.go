@@ -0,0 +1,81 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMoney_GoogleMoney(t *testing.T) {
+	tcs := []struct {
+		money    *Money
+		expected GoogleMoney
+	}{
+		{New(1234, USD), GoogleMoney{CurrencyCode: USD, Units: 12, Nanos: 340000000}},
+		{New(-1234, USD), GoogleMoney{CurrencyCode: USD, Units: -12, Nanos: -340000000}},
+		{New(-5, USD), GoogleMoney{CurrencyCode: USD, Units: 0, Nanos: -50000000}},
+		{New(1234, JPY), GoogleMoney{CurrencyCode: JPY, Units: 1234, Nanos: 0}},
+		{New(1001, KWD), GoogleMoney{CurrencyCode: KWD, Units: 1, Nanos: 1000000}},
+		{New(math.MinInt64, EUR), GoogleMoney{CurrencyCode: EUR, Units: -92233720368547758, Nanos: -80000000}},
+	}
+
+	for _, tc := range tcs {
+		g, err := tc.money.GoogleMoney()
+		if err != nil {
+			t.Errorf("GoogleMoney() error: %v", err)
+			continue
+		}
+
+		if g != tc.expected {
+			t.Errorf("Expected %+v got %+v", tc.expected, g)
+		}
+
+		m, err := NewFromGoogleMoney(g)
+		if err != nil {
+			t.Errorf("NewFromGoogleMoney(%+v) error: %v", g, err)
+			continue
+		}
+		if eq, err := m.Equals(tc.money); err != nil || !eq {
+			t.Errorf("Expected %s got %s", tc.money.Display(), m.Display())
+		}
+	}
+}
+
+func TestMoney_GoogleMoney_FractionTooLarge(t *testing.T) {
+	AddCurrency("GMT18", "T", "1 $", ".", ",", 18)
+
+	if _, err := New(1, "GMT18").GoogleMoney(); !errors.Is(err, ErrFractionTooLarge) {
+		t.Errorf("Expected ErrFractionTooLarge, got %v", err)
+	}
+
+	if _, err := NewFromGoogleMoney(GoogleMoney{CurrencyCode: "GMT18", Units: 1}); !errors.Is(err, ErrFractionTooLarge) {
+		t.Errorf("Expected ErrFractionTooLarge, got %v", err)
+	}
+}
+
+func TestMoney_GoogleMoney_NoCurrency(t *testing.T) {
+	_, err := (&Money{}).GoogleMoney()
+	if !errors.Is(err, ErrInvalidGoogleMoney) || !errors.Is(err, ErrNoCurrency) {
+		t.Errorf("Expected ErrInvalidGoogleMoney and ErrNoCurrency, got %v", err)
+	}
+}
+
+func TestNewFromGoogleMoney_Invalid(t *testing.T) {
+	tcs := []GoogleMoney{
+		{CurrencyCode: "", Units: 1},
+		{CurrencyCode: USD, Units: 1, Nanos: -1},
+		{CurrencyCode: USD, Units: -1, Nanos: 10000000},
+		{CurrencyCode: USD, Nanos: 1000000000},
+		{CurrencyCode: USD, Nanos: -1000000000},
+		{CurrencyCode: USD, Nanos: 1},
+		{CurrencyCode: JPY, Nanos: 500000000},
+		{CurrencyCode: USD, Units: math.MaxInt64},
+		{CurrencyCode: USD, Units: 92233720368547758, Nanos: 80000000},
+	}
+
+	for _, tc := range tcs {
+		if _, err := NewFromGoogleMoney(tc); !errors.Is(err, ErrInvalidGoogleMoney) {
+			t.Errorf("NewFromGoogleMoney(%+v) expected ErrInvalidGoogleMoney, got %v", tc, err)
+		}
+	}
+}
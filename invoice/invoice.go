@@ -0,0 +1,320 @@
+// Package invoice totals invoices and carts of [money.Money] line items with
+// discounts, shipping and tax.
+//
+// Discounts are percentages or fixed amounts of the subtotal, applied in order,
+// each to the subtotal left by the discounts before it. Every discount is
+// pro-rated across the items in proportion to their remaining amounts with
+// [money.Money.Allocate], so each item carries its exact share. Taxes are then
+// calculated on the discounted items and shipping with [tax.Calculate]:
+//
+//	vat, _ := tax.NewRate("VAT", "20")
+//	tenOff, _ := invoice.PercentDiscount("Spring sale", "10")
+//
+//	b, err := (&invoice.Invoice{
+//		Items: []invoice.Item{
+//			{Description: "Widget", UnitPrice: money.New(1999, money.GBP), Quantity: 3, Rates: []tax.Rate{vat}},
+//			{Description: "Gadget", UnitPrice: money.New(4500, money.GBP), Quantity: 1, Rates: []tax.Rate{vat}},
+//		},
+//		Discounts: []invoice.Discount{tenOff},
+//		Shipping:  money.New(499, money.GBP),
+//	}).Breakdown()
+//
+// The [Breakdown] always adds up exactly: the totals of the lines and shipping
+// sum to the invoice total, and so do their subtotals, discounts, net amounts
+// and taxes. It can be encoded as JSON.
+package invoice
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Rhymond/go-money"
+	"github.com/Rhymond/go-money/tax"
+)
+
+var (
+	// ErrNoItems is returned when totaling an invoice without items, which has no currency.
+	ErrNoItems = errors.New("no items")
+	// ErrInvalidItem is returned when an item has no or a negative unit price, or a quantity below one.
+	ErrInvalidItem = errors.New("invalid item")
+	// ErrInvalidDiscount is returned when a discount is not a percentage between 0 and 100
+	// or a non-negative amount.
+	ErrInvalidDiscount = errors.New("invalid discount")
+)
+
+// Item is a line item of an invoice.
+type Item struct {
+	Description string
+	UnitPrice   *money.Money
+	Quantity    int64
+	// Rates are the taxes charged on the item.
+	Rates []tax.Rate
+}
+
+// Discount is a percentage or a fixed amount taken off the subtotal of an invoice.
+type Discount struct {
+	Description string
+	// Percent is the exact percentage of the remaining subtotal taken off, e.g. 10 for 10%.
+	Percent *big.Rat
+	// Amount is the fixed amount taken off if Percent is nil. It is capped at
+	// the remaining subtotal.
+	Amount *money.Money
+}
+
+// PercentDiscount returns a Discount of an exact decimal percentage, such as "10" or "12.5".
+func PercentDiscount(description, percent string) (Discount, error) {
+	p, ok := new(big.Rat).SetString(percent)
+	if !ok {
+		return Discount{}, fmt.Errorf("%w: %s %q", ErrInvalidDiscount, description, percent)
+	}
+
+	return Discount{Description: description, Percent: p}, nil
+}
+
+// FixedDiscount returns a Discount of a fixed amount.
+func FixedDiscount(description string, m *money.Money) Discount {
+	return Discount{Description: description, Amount: m}
+}
+
+// Invoice is a list of items with discounts and shipping.
+type Invoice struct {
+	Items     []Item
+	Discounts []Discount
+	// Shipping is an optional shipping charge, which is not discounted.
+	Shipping *money.Money
+	// ShippingRates are the taxes charged on shipping.
+	ShippingRates []tax.Rate
+}
+
+// Line is the breakdown of an item or the shipping of an invoice.
+//
+// Subtotal minus Discount is the amount taxed, which is Net plus the amounts
+// of Taxes for exclusive pricing and Total for inclusive pricing. Net plus the
+// amounts of Taxes always equals Total.
+type Line struct {
+	Description string       `json:"description"`
+	Quantity    int64        `json:"quantity"`
+	UnitPrice   *money.Money `json:"unit_price"`
+	Subtotal    *money.Money `json:"subtotal"`
+	Discount    *money.Money `json:"discount"`
+	Net         *money.Money `json:"net"`
+	Taxes       []Tax        `json:"taxes"`
+	Total       *money.Money `json:"total"`
+}
+
+// DiscountTotal is the amount taken off by a discount.
+type DiscountTotal struct {
+	Description string       `json:"description"`
+	Amount      *money.Money `json:"amount"`
+}
+
+// Tax is the tax charged for a rate.
+type Tax struct {
+	Name string `json:"name"`
+	// Percent is the rate as a decimal percentage, e.g. "9.975".
+	Percent string       `json:"percent"`
+	Amount  *money.Money `json:"amount"`
+}
+
+// Breakdown is the total of an invoice. Subtotal and Discount are the sums of
+// the same amounts of Lines; Net, Taxes and Total are the sums of the same
+// amounts of Lines and Shipping.
+type Breakdown struct {
+	Lines []Line `json:"lines"`
+	// Shipping is nil if the invoice has no shipping charge.
+	Shipping  *Line           `json:"shipping,omitempty"`
+	Subtotal  *money.Money    `json:"subtotal"`
+	Discounts []DiscountTotal `json:"discounts"`
+	Discount  *money.Money    `json:"discount"`
+	Net       *money.Money    `json:"net"`
+	Taxes     []Tax           `json:"taxes"`
+	Total     *money.Money    `json:"total"`
+}
+
+// Breakdown totals the invoice, calculating its taxes with opts. Percentage
+// discounts are rounded with the rounding mode of opts.
+func (inv *Invoice) Breakdown(opts ...tax.Option) (*Breakdown, error) {
+	o := tax.DefaultOptions()
+	for _, opt := range opts {
+		o = opt(o)
+	}
+
+	if len(inv.Items) == 0 {
+		return nil, ErrNoItems
+	}
+
+	first := inv.Items[0].UnitPrice
+	if first == nil || first.Currency() == nil {
+		return nil, fmt.Errorf("%w: item 0 has no unit price", ErrInvalidItem)
+	}
+	code := first.Currency().Code
+	zero := money.New(0, code)
+
+	lines := make([]Line, len(inv.Items))
+	remaining := make([]*money.Money, len(inv.Items))
+	subtotal := zero
+	for i, it := range inv.Items {
+		if it.UnitPrice == nil || it.UnitPrice.Currency() == nil || it.UnitPrice.IsNegative() || it.Quantity < 1 {
+			return nil, fmt.Errorf("%w: item %d", ErrInvalidItem, i)
+		}
+		if !it.UnitPrice.SameCurrency(first) {
+			return nil, fmt.Errorf("item %d: %w", i, money.ErrCurrencyMismatch)
+		}
+
+		s := it.UnitPrice.Multiply(it.Quantity)
+		lines[i] = Line{Description: it.Description, Quantity: it.Quantity, UnitPrice: it.UnitPrice, Subtotal: s, Discount: zero}
+		remaining[i] = s
+
+		var err error
+		if subtotal, err = subtotal.Add(s); err != nil {
+			return nil, err
+		}
+	}
+
+	b := &Breakdown{Subtotal: subtotal, Discounts: []DiscountTotal{}, Discount: zero, Taxes: []Tax{}}
+	left := subtotal
+	for i, d := range inv.Discounts {
+		amount, err := discountAmount(d, left, o.RoundingMode)
+		if err != nil {
+			return nil, fmt.Errorf("discount %d: %w", i, err)
+		}
+
+		shares, err := prorate(amount, remaining)
+		if err != nil {
+			return nil, err
+		}
+		for j, s := range shares {
+			if remaining[j], err = remaining[j].Subtract(s); err != nil {
+				return nil, err
+			}
+			if lines[j].Discount, err = lines[j].Discount.Add(s); err != nil {
+				return nil, err
+			}
+		}
+
+		if left, err = left.Subtract(amount); err != nil {
+			return nil, err
+		}
+		if b.Discount, err = b.Discount.Add(amount); err != nil {
+			return nil, err
+		}
+		b.Discounts = append(b.Discounts, DiscountTotal{Description: d.Description, Amount: amount})
+	}
+
+	taxLines := make([]tax.Line, len(lines), len(lines)+1)
+	for i, it := range inv.Items {
+		taxLines[i] = tax.Line{Amount: remaining[i], Rates: it.Rates}
+	}
+	if inv.Shipping != nil {
+		if !inv.Shipping.SameCurrency(first) {
+			return nil, fmt.Errorf("shipping: %w", money.ErrCurrencyMismatch)
+		}
+		taxLines = append(taxLines, tax.Line{Amount: inv.Shipping, Rates: inv.ShippingRates})
+	}
+
+	tb, err := tax.Calculate(taxLines, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range lines {
+		setTaxes(&lines[i], tb.Lines[i])
+	}
+	b.Lines = lines
+	if inv.Shipping != nil {
+		b.Shipping = &Line{Description: "Shipping", Quantity: 1, UnitPrice: inv.Shipping, Subtotal: inv.Shipping, Discount: zero}
+		setTaxes(b.Shipping, tb.Lines[len(lines)])
+	}
+
+	b.Net = tb.Net
+	b.Total = tb.Gross
+	for _, t := range tb.Taxes {
+		b.Taxes = append(b.Taxes, newTax(t))
+	}
+
+	return b, nil
+}
+
+// discountAmount returns the amount d takes off the remaining subtotal left.
+func discountAmount(d Discount, left *money.Money, mode money.RoundingMode) (*money.Money, error) {
+	if d.Percent != nil {
+		if d.Percent.Sign() < 0 || d.Percent.Cmp(big.NewRat(100, 1)) > 0 {
+			return nil, fmt.Errorf("%w: %s%%", ErrInvalidDiscount, d.Percent.FloatString(2))
+		}
+		return left.MultiplyRat(new(big.Rat).Quo(d.Percent, big.NewRat(100, 1)), mode)
+	}
+
+	if d.Amount == nil || d.Amount.Currency() == nil || d.Amount.IsNegative() {
+		return nil, fmt.Errorf("%w: %s needs a percentage or a non-negative amount", ErrInvalidDiscount, d.Description)
+	}
+	if !d.Amount.SameCurrency(left) {
+		return nil, money.ErrCurrencyMismatch
+	}
+
+	if gt, err := d.Amount.GreaterThan(left); err != nil {
+		return nil, err
+	} else if gt {
+		return left, nil
+	}
+
+	return d.Amount, nil
+}
+
+// prorate allocates amount to parts in proportion to their amounts. Parts
+// with nothing left get none of amount.
+func prorate(amount *money.Money, parts []*money.Money) ([]*money.Money, error) {
+	res := make([]*money.Money, len(parts))
+
+	var idx []int
+	var ratios []int
+	for i, p := range parts {
+		res[i] = money.New(0, amount.Currency().Code)
+		if p.IsPositive() {
+			idx = append(idx, i)
+			ratios = append(ratios, int(p.Amount()))
+		}
+	}
+
+	// nothing is left to discount, so amount is zero
+	if len(idx) == 0 {
+		return res, nil
+	}
+
+	shares, err := amount.Allocate(ratios...)
+	if err != nil {
+		return nil, err
+	}
+	for n, i := range idx {
+		res[i] = shares[n]
+	}
+
+	return res, nil
+}
+
+// setTaxes copies the tax breakdown of a line into l.
+func setTaxes(l *Line, tl tax.LineBreakdown) {
+	l.Net = tl.Net
+	l.Total = tl.Gross
+	l.Taxes = make([]Tax, len(tl.Taxes))
+	for i, t := range tl.Taxes {
+		l.Taxes[i] = newTax(t)
+	}
+}
+
+func newTax(t tax.TaxAmount) Tax {
+	return Tax{Name: t.Rate.Name, Percent: decimalString(t.Rate.Percent), Amount: t.Amount}
+}
+
+// decimalString returns r as a decimal string without trailing zeros, or as a
+// fraction if it has no finite decimal representation.
+func decimalString(r *big.Rat) string {
+	for prec := 0; prec <= 18; prec++ {
+		s := r.FloatString(prec)
+		if f, ok := new(big.Rat).SetString(s); ok && f.Cmp(r) == 0 {
+			return s
+		}
+	}
+
+	return r.RatString()
+}
@@ -0,0 +1,245 @@
+package invoice
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+	"github.com/Rhymond/go-money/tax"
+)
+
+// checkBreakdown asserts that the parts of b add up exactly.
+func checkBreakdown(t *testing.T, b *Breakdown) {
+	t.Helper()
+
+	lines := b.Lines
+	if b.Shipping != nil {
+		lines = append(lines[:len(lines):len(lines)], *b.Shipping)
+	}
+
+	var subtotal, discount, net, taxTotal, total int64
+	for i, l := range lines {
+		lineTax := int64(0)
+		for _, tx := range l.Taxes {
+			lineTax += tx.Amount.Amount()
+		}
+		if l.Net.Amount()+lineTax != l.Total.Amount() {
+			t.Errorf("Expected line %d net %d + tax %d to be total %d", i, l.Net.Amount(), lineTax, l.Total.Amount())
+		}
+		if i < len(b.Lines) {
+			subtotal += l.Subtotal.Amount()
+			discount += l.Discount.Amount()
+		}
+		net += l.Net.Amount()
+		taxTotal += lineTax
+		total += l.Total.Amount()
+	}
+
+	var discounts, taxes int64
+	for _, d := range b.Discounts {
+		discounts += d.Amount.Amount()
+	}
+	for _, tx := range b.Taxes {
+		taxes += tx.Amount.Amount()
+	}
+
+	if subtotal != b.Subtotal.Amount() || discount != b.Discount.Amount() || discounts != discount {
+		t.Errorf("Expected subtotal %d discount %d got %d %d (discounts %d)", subtotal, discount, b.Subtotal.Amount(), b.Discount.Amount(), discounts)
+	}
+	if net != b.Net.Amount() || taxTotal != taxes || total != b.Total.Amount() {
+		t.Errorf("Expected net %d tax %d total %d got %d %d %d", net, taxTotal, total, b.Net.Amount(), taxes, b.Total.Amount())
+	}
+}
+
+func TestInvoice_Breakdown(t *testing.T) {
+	vat, err := tax.NewRate("VAT", "20")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenOff, err := PercentDiscount("Spring sale", "10")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inv := &Invoice{
+		Items: []Item{
+			{Description: "Widget", UnitPrice: money.New(1999, money.GBP), Quantity: 3, Rates: []tax.Rate{vat}},
+			{Description: "Gadget", UnitPrice: money.New(4500, money.GBP), Quantity: 1, Rates: []tax.Rate{vat}},
+		},
+		Discounts: []Discount{tenOff},
+		Shipping:  money.New(499, money.GBP),
+	}
+
+	b, err := inv.Breakdown()
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkBreakdown(t, b)
+
+	want := []struct {
+		subtotal, discount, net, tax, total int64
+	}{
+		{5997, 600, 5397, 1079, 6476},
+		{4500, 450, 4050, 810, 4860},
+	}
+	for i, w := range want {
+		l := b.Lines[i]
+		if l.Subtotal.Amount() != w.subtotal || l.Discount.Amount() != w.discount || l.Net.Amount() != w.net ||
+			l.Taxes[0].Amount.Amount() != w.tax || l.Total.Amount() != w.total {
+			t.Errorf("Expected line %d %+v got %d %d %d %d %d", i, w, l.Subtotal.Amount(), l.Discount.Amount(),
+				l.Net.Amount(), l.Taxes[0].Amount.Amount(), l.Total.Amount())
+		}
+	}
+
+	if b.Subtotal.Amount() != 10497 || b.Discount.Amount() != 1050 || b.Net.Amount() != 9946 || b.Total.Amount() != 11835 {
+		t.Errorf("Expected 104.97 - 10.50 = 99.46 net, 118.35 total got %s - %s = %s net, %s total",
+			b.Subtotal.Display(), b.Discount.Display(), b.Net.Display(), b.Total.Display())
+	}
+	if b.Shipping == nil || b.Shipping.Total.Amount() != 499 || len(b.Shipping.Taxes) != 0 {
+		t.Errorf("Expected untaxed shipping of 499 got %+v", b.Shipping)
+	}
+
+	data, err := json.Marshal(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, s := range []string{
+		`"total":{"amount":11835,"currency":"GBP"}`,
+		`"discounts":[{"description":"Spring sale","amount":{"amount":1050,"currency":"GBP"}}]`,
+		`"taxes":[{"name":"VAT","percent":"20","amount":{"amount":1889,"currency":"GBP"}}]`,
+	} {
+		if !strings.Contains(string(data), s) {
+			t.Errorf("Expected %s to contain %s", data, s)
+		}
+	}
+}
+
+func TestInvoice_Discounts(t *testing.T) {
+	half, _ := PercentDiscount("Half", "50")
+	tenth, _ := PercentDiscount("Tenth", "10")
+
+	tests := []struct {
+		name      string
+		prices    []int64
+		discounts []Discount
+		want      []int64
+	}{
+		{
+			name:      "fixed capped at subtotal",
+			prices:    []int64{300, 200},
+			discounts: []Discount{FixedDiscount("Voucher", money.New(1000, money.EUR))},
+			want:      []int64{300, 200},
+		},
+		{
+			name:      "fixed then percent",
+			prices:    []int64{1000, 3000},
+			discounts: []Discount{FixedDiscount("Voucher", money.New(400, money.EUR)), half},
+			want:      []int64{550, 1650},
+		},
+		{
+			name:      "free items get no discount",
+			prices:    []int64{0, 333, 334},
+			discounts: []Discount{tenth},
+			want:      []int64{0, 34, 33},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			inv := &Invoice{Discounts: tc.discounts}
+			for _, p := range tc.prices {
+				inv.Items = append(inv.Items, Item{UnitPrice: money.New(p, money.EUR), Quantity: 1})
+			}
+
+			b, err := inv.Breakdown()
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkBreakdown(t, b)
+
+			for i, w := range tc.want {
+				if got := b.Lines[i].Discount.Amount(); got != w {
+					t.Errorf("Expected line %d discount %d got %d", i, w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestInvoice_AddsUp(t *testing.T) {
+	gst, _ := tax.NewRate("GST", "5")
+	qst, _ := tax.NewRate("QST", "9.975")
+	sale, _ := PercentDiscount("Sale", "12.5")
+
+	for _, pricing := range []tax.Pricing{tax.Exclusive, tax.Inclusive} {
+		for _, rounding := range []tax.Rounding{tax.PerLine, tax.PerInvoice} {
+			for p := int64(1); p < 3000; p += 97 {
+				inv := &Invoice{
+					Items: []Item{
+						{UnitPrice: money.New(p, money.CAD), Quantity: 3, Rates: []tax.Rate{gst, qst}},
+						{UnitPrice: money.New(p*7+13, money.CAD), Quantity: 1, Rates: []tax.Rate{gst}},
+						{UnitPrice: money.New(99, money.CAD), Quantity: 2},
+					},
+					Discounts:     []Discount{sale, FixedDiscount("Coupon", money.New(p/3, money.CAD))},
+					Shipping:      money.New(p%500, money.CAD),
+					ShippingRates: []tax.Rate{gst},
+				}
+
+				b, err := inv.Breakdown(tax.WithPricing(pricing), tax.WithRounding(rounding))
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkBreakdown(t, b)
+			}
+		}
+	}
+}
+
+func TestInvoice_Errors(t *testing.T) {
+	tooMuch, _ := PercentDiscount("Too much", "150")
+
+	tests := []struct {
+		name string
+		inv  Invoice
+		err  error
+	}{
+		{"no items", Invoice{}, ErrNoItems},
+		{"no unit price", Invoice{Items: []Item{{Quantity: 1}}}, ErrInvalidItem},
+		{"negative unit price", Invoice{Items: []Item{{UnitPrice: money.New(-1, money.USD), Quantity: 1}}}, ErrInvalidItem},
+		{"zero quantity", Invoice{Items: []Item{{UnitPrice: money.New(1, money.USD)}}}, ErrInvalidItem},
+		{"item currency", Invoice{Items: []Item{
+			{UnitPrice: money.New(1, money.USD), Quantity: 1},
+			{UnitPrice: money.New(1, money.EUR), Quantity: 1},
+		}}, money.ErrCurrencyMismatch},
+		{"shipping currency", Invoice{
+			Items:    []Item{{UnitPrice: money.New(1, money.USD), Quantity: 1}},
+			Shipping: money.New(1, money.EUR),
+		}, money.ErrCurrencyMismatch},
+		{"discount currency", Invoice{
+			Items:     []Item{{UnitPrice: money.New(1, money.USD), Quantity: 1}},
+			Discounts: []Discount{FixedDiscount("", money.New(1, money.EUR))},
+		}, money.ErrCurrencyMismatch},
+		{"negative discount", Invoice{
+			Items:     []Item{{UnitPrice: money.New(1, money.USD), Quantity: 1}},
+			Discounts: []Discount{FixedDiscount("", money.New(-1, money.USD))},
+		}, ErrInvalidDiscount},
+		{"discount over 100%", Invoice{
+			Items:     []Item{{UnitPrice: money.New(1, money.USD), Quantity: 1}},
+			Discounts: []Discount{tooMuch},
+		}, ErrInvalidDiscount},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.inv.Breakdown(); !errors.Is(err, tc.err) {
+				t.Errorf("Expected %v got %v", tc.err, err)
+			}
+		})
+	}
+
+	if _, err := PercentDiscount("", "ten"); !errors.Is(err, ErrInvalidDiscount) {
+		t.Errorf("Expected %v got %v", ErrInvalidDiscount, err)
+	}
+}
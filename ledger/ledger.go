@@ -0,0 +1,465 @@
+// Package ledger is a double-entry bookkeeping ledger of [money.Money] amounts.
+//
+// A [Ledger] holds [Account] values of the five account types and records
+// [Entry] values made of debit and credit postings. Entries are only recorded
+// if their debits equal their credits in every currency, so the ledger as a
+// whole always balances:
+//
+//	l := ledger.New(nil)
+//	_ = l.Open(ledger.Account{Code: "1000", Name: "Cash", Type: ledger.Asset})
+//	_ = l.Open(ledger.Account{Code: "4000", Name: "Sales", Type: ledger.Income})
+//
+//	err := l.Post(&ledger.Entry{
+//		Description: "Cash sale",
+//		Postings: []ledger.Posting{
+//			ledger.Debit("1000", money.New(12000, money.USD)),
+//			ledger.Credit("4000", money.New(12000, money.USD)),
+//		},
+//	})
+//
+// Balances are reported on the normal side of an account: debits increase
+// assets and expenses, and credits increase liabilities, equity and income.
+// All arithmetic is done with [money.Money.Add] and [money.Money.Subtract].
+//
+// Accounts and entries are kept in a [Store]. [MemoryStore] keeps them in
+// memory; other implementations can keep them in a database.
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Rhymond/go-money"
+)
+
+var (
+	// ErrInvalidAccount is returned when opening an account without a code or with an unknown type.
+	ErrInvalidAccount = errors.New("invalid account")
+	// ErrDuplicateAccount is returned when opening an account whose code is already in use.
+	ErrDuplicateAccount = errors.New("duplicate account")
+	// ErrUnknownAccount is returned when an account code does not exist in the ledger.
+	ErrUnknownAccount = errors.New("unknown account")
+	// ErrInvalidPosting is returned when a posting has no amount, a negative amount or an unknown side.
+	ErrInvalidPosting = errors.New("invalid posting")
+	// ErrTooFewPostings is returned when an entry has less than two postings.
+	ErrTooFewPostings = errors.New("entry needs at least two postings")
+	// ErrUnbalanced is returned when the debits of an entry don't equal its credits in a currency.
+	ErrUnbalanced = errors.New("entry is unbalanced")
+)
+
+// AccountType classifies an account.
+type AccountType int
+
+const (
+	// Asset accounts hold what is owned, such as cash and receivables.
+	Asset AccountType = iota
+	// Liability accounts hold what is owed, such as payables and loans.
+	Liability
+	// Equity accounts hold the owners' share, such as capital and retained earnings.
+	Equity
+	// Income accounts hold revenue, such as sales.
+	Income
+	// Expense accounts hold costs, such as rent and wages.
+	Expense
+)
+
+var accountTypeNames = [...]string{
+	Asset:     "asset",
+	Liability: "liability",
+	Equity:    "equity",
+	Income:    "income",
+	Expense:   "expense",
+}
+
+func (t AccountType) String() string {
+	if !t.valid() {
+		return fmt.Sprintf("AccountType(%d)", int(t))
+	}
+	return accountTypeNames[t]
+}
+
+// NormalSide returns the side that increases the balance of accounts of type t:
+// Debit for assets and expenses and Credit for the others.
+func (t AccountType) NormalSide() Side {
+	if t == Asset || t == Expense {
+		return DebitSide
+	}
+	return CreditSide
+}
+
+func (t AccountType) valid() bool {
+	return t >= Asset && t <= Expense
+}
+
+// Side is the side of an account a posting is made to.
+type Side int
+
+const (
+	// DebitSide is the left side of an account.
+	DebitSide Side = iota
+	// CreditSide is the right side of an account.
+	CreditSide
+)
+
+func (s Side) String() string {
+	switch s {
+	case DebitSide:
+		return "debit"
+	case CreditSide:
+		return "credit"
+	default:
+		return fmt.Sprintf("Side(%d)", int(s))
+	}
+}
+
+// Account is an account of a ledger, identified by its code.
+type Account struct {
+	Code string
+	Name string
+	Type AccountType
+}
+
+// Posting is a debit or credit of a non-negative amount to an account.
+type Posting struct {
+	Account string
+	Side    Side
+	Amount  *money.Money
+}
+
+// Debit returns a posting debiting m to the account with the given code.
+func Debit(account string, m *money.Money) Posting {
+	return Posting{Account: account, Side: DebitSide, Amount: m}
+}
+
+// Credit returns a posting crediting m to the account with the given code.
+func Credit(account string, m *money.Money) Posting {
+	return Posting{Account: account, Side: CreditSide, Amount: m}
+}
+
+// Entry is a journal entry: a set of postings whose debits equal their credits
+// in every currency.
+type Entry struct {
+	// ID is assigned by the Store when the entry is posted, starting at 1.
+	ID          int64
+	Date        time.Time
+	Description string
+	Postings    []Posting
+}
+
+// Ledger records balanced entries to accounts kept in a Store.
+type Ledger struct {
+	store Store
+}
+
+// New returns a Ledger keeping its accounts and entries in s.
+// If s is nil, a new MemoryStore is used.
+func New(s Store) *Ledger {
+	if s == nil {
+		s = NewMemoryStore()
+	}
+	return &Ledger{store: s}
+}
+
+// Open adds account a to the ledger.
+func (l *Ledger) Open(a Account) error {
+	if a.Code == "" {
+		return fmt.Errorf("%w: empty code", ErrInvalidAccount)
+	}
+	if !a.Type.valid() {
+		return fmt.Errorf("%w: %s has type %s", ErrInvalidAccount, a.Code, a.Type)
+	}
+
+	return l.store.CreateAccount(a)
+}
+
+// Account returns the account with the given code.
+func (l *Ledger) Account(code string) (Account, error) {
+	return l.store.Account(code)
+}
+
+// Accounts returns all accounts sorted by code.
+func (l *Ledger) Accounts() ([]Account, error) {
+	return l.store.Accounts()
+}
+
+// Post validates e and records it, setting e.ID.
+// It returns ErrUnbalanced if the debits of e don't equal its credits in a currency.
+func (l *Ledger) Post(e *Entry) error {
+	if len(e.Postings) < 2 {
+		return ErrTooFewPostings
+	}
+
+	debits := make(map[string]*money.Money)
+	credits := make(map[string]*money.Money)
+	for i, p := range e.Postings {
+		if p.Amount == nil || p.Amount.Currency() == nil {
+			return fmt.Errorf("%w: posting %d has no amount", ErrInvalidPosting, i)
+		}
+		if p.Amount.IsNegative() {
+			return fmt.Errorf("%w: posting %d has negative amount %s", ErrInvalidPosting, i, p.Amount.Display())
+		}
+		if _, err := l.store.Account(p.Account); err != nil {
+			return fmt.Errorf("posting %d: %w", i, err)
+		}
+
+		var totals map[string]*money.Money
+		switch p.Side {
+		case DebitSide:
+			totals = debits
+		case CreditSide:
+			totals = credits
+		default:
+			return fmt.Errorf("%w: posting %d has side %s", ErrInvalidPosting, i, p.Side)
+		}
+
+		code := p.Amount.Currency().Code
+		initTotals(code, debits, credits)
+		if err := addTo(totals, code, p.Amount); err != nil {
+			return err
+		}
+	}
+
+	for _, code := range sortedKeys(debits) {
+		if eq, err := debits[code].Equals(credits[code]); err != nil {
+			return err
+		} else if !eq {
+			return fmt.Errorf("%w: %s debits %s, credits %s", ErrUnbalanced, code, debits[code].Display(), credits[code].Display())
+		}
+	}
+
+	id, err := l.store.AppendEntry(*e)
+	if err != nil {
+		return err
+	}
+
+	e.ID = id
+	return nil
+}
+
+// Entries returns the entries posting to the account with the given code in
+// the order they were posted, or all entries if code is empty.
+func (l *Ledger) Entries(code string) ([]Entry, error) {
+	if code != "" {
+		if _, err := l.store.Account(code); err != nil {
+			return nil, err
+		}
+	}
+
+	return l.store.Entries(code)
+}
+
+// Balance returns the balance of the account with the given code in a currency,
+// on the normal side of the account. The currency code is case-insensitive.
+func (l *Ledger) Balance(code, currency string) (*money.Money, error) {
+	lines, err := l.Statement(code)
+	if err != nil {
+		return nil, err
+	}
+
+	currency = strings.ToUpper(currency)
+	for i := len(lines) - 1; i >= 0; i-- {
+		if lines[i].Balance.Currency().Code == currency {
+			return lines[i].Balance, nil
+		}
+	}
+
+	return money.New(0, currency), nil
+}
+
+// Balances returns the balances of the account with the given code in every
+// currency posted to it, on the normal side of the account, sorted by currency code.
+func (l *Ledger) Balances(code string) ([]*money.Money, error) {
+	lines, err := l.Statement(code)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]*money.Money)
+	for _, line := range lines {
+		balances[line.Balance.Currency().Code] = line.Balance
+	}
+
+	res := make([]*money.Money, 0, len(balances))
+	for _, c := range sortedKeys(balances) {
+		res = append(res, balances[c])
+	}
+
+	return res, nil
+}
+
+// StatementLine is a posting to an account with the running balance of the
+// account in the currency of the posting after it.
+type StatementLine struct {
+	Entry   int64
+	Date    time.Time
+	Posting Posting
+	Balance *money.Money
+}
+
+// Statement returns the postings to the account with the given code in the
+// order they were posted, with running balances on the normal side of the account.
+func (l *Ledger) Statement(code string) ([]StatementLine, error) {
+	a, err := l.store.Account(code)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := l.store.Entries(code)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]*money.Money)
+	var lines []StatementLine
+	for _, e := range entries {
+		for _, p := range e.Postings {
+			if p.Account != code {
+				continue
+			}
+
+			c := p.Amount.Currency().Code
+			b, ok := balances[c]
+			if !ok {
+				b = money.New(0, c)
+			}
+
+			if p.Side == a.Type.NormalSide() {
+				b, err = b.Add(p.Amount)
+			} else {
+				b, err = b.Subtract(p.Amount)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			balances[c] = b
+			lines = append(lines, StatementLine{Entry: e.ID, Date: e.Date, Posting: p, Balance: b})
+		}
+	}
+
+	return lines, nil
+}
+
+// TrialBalance lists the balance of every account in every currency as a debit
+// or a credit, with the totals of both columns per currency.
+type TrialBalance struct {
+	// Rows are sorted by account code and currency code; zero balances are left out.
+	Rows []TrialBalanceRow
+	// Totals are sorted by currency code.
+	Totals []TrialBalanceTotal
+}
+
+// TrialBalanceRow is the balance of an account in a currency. One of Debit and
+// Credit holds the balance and the other is zero.
+type TrialBalanceRow struct {
+	Account Account
+	Debit   *money.Money
+	Credit  *money.Money
+}
+
+// TrialBalanceTotal holds the totals of the debit and credit columns of a trial balance in a currency.
+type TrialBalanceTotal struct {
+	Debit  *money.Money
+	Credit *money.Money
+}
+
+// Balanced reports whether the debit and credit totals are equal in every currency.
+func (tb *TrialBalance) Balanced() bool {
+	for _, t := range tb.Totals {
+		if eq, err := t.Debit.Equals(t.Credit); err != nil || !eq {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TrialBalance returns the trial balance of the ledger.
+func (l *Ledger) TrialBalance() (*TrialBalance, error) {
+	accounts, err := l.store.Accounts()
+	if err != nil {
+		return nil, err
+	}
+
+	tb := &TrialBalance{}
+	debits := make(map[string]*money.Money)
+	credits := make(map[string]*money.Money)
+	for _, a := range accounts {
+		balances, err := l.Balances(a.Code)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range balances {
+			if b.IsZero() {
+				continue
+			}
+
+			c := b.Currency().Code
+			zero := money.New(0, c)
+			initTotals(c, debits, credits)
+
+			// a negative balance is shown on the other side of the account
+			debit := a.Type.NormalSide() == DebitSide
+			if b.IsNegative() {
+				if b, err = zero.Subtract(b); err != nil {
+					return nil, err
+				}
+				debit = !debit
+			}
+
+			row := TrialBalanceRow{Account: a, Debit: zero, Credit: zero}
+			if debit {
+				row.Debit = b
+				err = addTo(debits, c, b)
+			} else {
+				row.Credit = b
+				err = addTo(credits, c, b)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			tb.Rows = append(tb.Rows, row)
+		}
+	}
+
+	for _, c := range sortedKeys(debits) {
+		tb.Totals = append(tb.Totals, TrialBalanceTotal{Debit: debits[c], Credit: credits[c]})
+	}
+
+	return tb, nil
+}
+
+// initTotals sets the totals of currency c to zero in every map of totals that lacks it.
+func initTotals(c string, totals ...map[string]*money.Money) {
+	for _, t := range totals {
+		if _, ok := t[c]; !ok {
+			t[c] = money.New(0, c)
+		}
+	}
+}
+
+// addTo adds m to the total of currency c in totals.
+func addTo(totals map[string]*money.Money, c string, m *money.Money) error {
+	t, err := totals[c].Add(m)
+	if err != nil {
+		return err
+	}
+
+	totals[c] = t
+	return nil
+}
+
+func sortedKeys(m map[string]*money.Money) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
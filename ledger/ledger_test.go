@@ -0,0 +1,291 @@
+package ledger
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+)
+
+func newTestLedger(t *testing.T) *Ledger {
+	t.Helper()
+
+	l := New(nil)
+	for _, a := range []Account{
+		{Code: "1000", Name: "Cash", Type: Asset},
+		{Code: "2000", Name: "Loan", Type: Liability},
+		{Code: "3000", Name: "Capital", Type: Equity},
+		{Code: "4000", Name: "Sales", Type: Income},
+		{Code: "5000", Name: "Rent", Type: Expense},
+	} {
+		if err := l.Open(a); err != nil {
+			t.Fatalf("Open(%s) error = %v", a.Code, err)
+		}
+	}
+
+	return l
+}
+
+func mustPost(t *testing.T, l *Ledger, ps ...Posting) {
+	t.Helper()
+
+	if err := l.Post(&Entry{Postings: ps}); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+}
+
+func TestLedger_Open(t *testing.T) {
+	l := newTestLedger(t)
+
+	tests := []struct {
+		account Account
+		err     error
+	}{
+		{Account{Code: "", Type: Asset}, ErrInvalidAccount},
+		{Account{Code: "6000", Type: AccountType(9)}, ErrInvalidAccount},
+		{Account{Code: "1000", Type: Asset}, ErrDuplicateAccount},
+	}
+
+	for _, tc := range tests {
+		if err := l.Open(tc.account); !errors.Is(err, tc.err) {
+			t.Errorf("Open(%+v) error = %v, want %v", tc.account, err, tc.err)
+		}
+	}
+
+	accounts, err := l.Accounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accounts) != 5 || accounts[0].Code != "1000" || accounts[4].Code != "5000" {
+		t.Errorf("Expected 5 accounts sorted by code got %+v", accounts)
+	}
+}
+
+func TestLedger_Post(t *testing.T) {
+	l := newTestLedger(t)
+
+	e := &Entry{Postings: []Posting{
+		Debit("1000", money.New(10000, money.USD)),
+		Debit("1000", money.New(5000, money.EUR)),
+		Credit("3000", money.New(10000, money.USD)),
+		Credit("3000", money.New(5000, money.EUR)),
+	}}
+	if err := l.Post(e); err != nil {
+		t.Fatalf("Post() error = %v", err)
+	}
+	if e.ID != 1 {
+		t.Errorf("Expected ID 1 got %d", e.ID)
+	}
+
+	tests := []struct {
+		name     string
+		postings []Posting
+		err      error
+	}{
+		{
+			name:     "one posting",
+			postings: []Posting{Debit("1000", money.New(0, money.USD))},
+			err:      ErrTooFewPostings,
+		},
+		{
+			name: "unbalanced",
+			postings: []Posting{
+				Debit("1000", money.New(100, money.USD)),
+				Credit("4000", money.New(99, money.USD)),
+			},
+			err: ErrUnbalanced,
+		},
+		{
+			name: "unbalanced per currency",
+			postings: []Posting{
+				Debit("1000", money.New(100, money.USD)),
+				Credit("4000", money.New(100, money.EUR)),
+			},
+			err: ErrUnbalanced,
+		},
+		{
+			name: "negative amount",
+			postings: []Posting{
+				Debit("1000", money.New(-100, money.USD)),
+				Credit("4000", money.New(-100, money.USD)),
+			},
+			err: ErrInvalidPosting,
+		},
+		{
+			name: "nil amount",
+			postings: []Posting{
+				Debit("1000", nil),
+				Credit("4000", money.New(100, money.USD)),
+			},
+			err: ErrInvalidPosting,
+		},
+		{
+			name: "unknown side",
+			postings: []Posting{
+				{Account: "1000", Side: Side(5), Amount: money.New(100, money.USD)},
+				Credit("4000", money.New(100, money.USD)),
+			},
+			err: ErrInvalidPosting,
+		},
+		{
+			name: "unknown account",
+			postings: []Posting{
+				Debit("1999", money.New(100, money.USD)),
+				Credit("4000", money.New(100, money.USD)),
+			},
+			err: ErrUnknownAccount,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := l.Post(&Entry{Postings: tc.postings})
+			if !errors.Is(err, tc.err) {
+				t.Errorf("Post() error = %v, want %v", err, tc.err)
+			}
+		})
+	}
+
+	entries, err := l.Entries("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected rejected entries not to be recorded got %d entries", len(entries))
+	}
+}
+
+func TestLedger_Statement(t *testing.T) {
+	l := newTestLedger(t)
+	mustPost(t, l, Debit("1000", money.New(100000, money.USD)), Credit("2000", money.New(100000, money.USD)))
+	mustPost(t, l, Debit("5000", money.New(25000, money.USD)), Credit("1000", money.New(25000, money.USD)))
+	mustPost(t, l, Debit("1000", money.New(4000, money.USD)), Credit("4000", money.New(4000, money.USD)))
+
+	lines, err := l.Statement("1000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int64{100000, 75000, 79000}
+	if len(lines) != len(want) {
+		t.Fatalf("Expected %d lines got %d", len(want), len(lines))
+	}
+	for i, line := range lines {
+		if line.Entry != int64(i+1) || line.Balance.Amount() != want[i] {
+			t.Errorf("Expected entry %d balance %d got entry %d balance %d", i+1, want[i], line.Entry, line.Balance.Amount())
+		}
+	}
+
+	b, err := l.Balance("2000", money.USD)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Amount() != 100000 {
+		t.Errorf("Expected liability balance 100000 got %d", b.Amount())
+	}
+
+	mustPost(t, l, Debit("1000", money.New(100, "usd")), Credit("4000", money.New(100, "usd")))
+	b, err = l.Balance("1000", "usd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Amount() != 79100 || b.Currency().Code != money.USD {
+		t.Errorf("Expected USD 791.00 got %s", b.Display())
+	}
+
+	b, err = l.Balance("2000", money.EUR)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.IsZero() || b.Currency().Code != money.EUR {
+		t.Errorf("Expected EUR 0 got %s", b.Display())
+	}
+
+	if _, err := l.Statement("9999"); !errors.Is(err, ErrUnknownAccount) {
+		t.Errorf("Expected %v got %v", ErrUnknownAccount, err)
+	}
+}
+
+func TestLedger_Balances(t *testing.T) {
+	l := newTestLedger(t)
+	mustPost(t, l, Debit("1000", money.New(500, money.USD)), Credit("4000", money.New(500, money.USD)))
+	mustPost(t, l, Debit("1000", money.New(700, money.EUR)), Credit("4000", money.New(700, money.EUR)))
+
+	balances, err := l.Balances("4000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(balances) != 2 || balances[0].Currency().Code != money.EUR || balances[0].Amount() != 700 ||
+		balances[1].Currency().Code != money.USD || balances[1].Amount() != 500 {
+		t.Errorf("Expected EUR 700 and USD 500 got %v", balances)
+	}
+}
+
+func TestLedger_TrialBalance(t *testing.T) {
+	l := newTestLedger(t)
+	mustPost(t, l, Debit("1000", money.New(100000, money.USD)), Credit("3000", money.New(100000, money.USD)))
+	mustPost(t, l, Debit("5000", money.New(150000, money.USD)), Credit("1000", money.New(150000, money.USD)))
+	mustPost(t, l, Debit("1000", money.New(2000, money.EUR)), Credit("4000", money.New(2000, money.EUR)))
+
+	tb, err := l.TrialBalance()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		account string
+		debit   int64
+		credit  int64
+	}{
+		{"1000", 2000, 0},
+		{"1000", 0, 50000}, // overdrawn cash is shown as a credit
+		{"3000", 0, 100000},
+		{"4000", 0, 2000},
+		{"5000", 150000, 0},
+	}
+	if len(tb.Rows) != len(want) {
+		t.Fatalf("Expected %d rows got %d", len(want), len(tb.Rows))
+	}
+	for i, w := range want {
+		r := tb.Rows[i]
+		if r.Account.Code != w.account || r.Debit.Amount() != w.debit || r.Credit.Amount() != w.credit {
+			t.Errorf("Expected %s %d/%d got %s %d/%d", w.account, w.debit, w.credit, r.Account.Code, r.Debit.Amount(), r.Credit.Amount())
+		}
+	}
+
+	if len(tb.Totals) != 2 {
+		t.Fatalf("Expected 2 totals got %d", len(tb.Totals))
+	}
+	if c := tb.Totals[1].Debit.Currency().Code; c != money.USD || tb.Totals[1].Debit.Amount() != 150000 {
+		t.Errorf("Expected USD debit total 150000 got %s %d", c, tb.Totals[1].Debit.Amount())
+	}
+	if !tb.Balanced() {
+		t.Errorf("Expected trial balance to balance")
+	}
+}
+
+func TestMemoryStore_Entries(t *testing.T) {
+	s := NewMemoryStore()
+	l := New(s)
+	for _, a := range []Account{{Code: "A", Type: Asset}, {Code: "B", Type: Asset}, {Code: "C", Type: Asset}} {
+		if err := l.Open(a); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustPost(t, l, Debit("A", money.New(1, money.USD)), Credit("B", money.New(1, money.USD)))
+	mustPost(t, l, Debit("B", money.New(1, money.USD)), Credit("C", money.New(1, money.USD)))
+
+	entries, err := s.Entries("B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].ID != 1 || entries[1].ID != 2 {
+		t.Errorf("Expected entries 1 and 2 got %+v", entries)
+	}
+
+	// changing returned entries must not change the store
+	entries[0].Postings[0] = Debit("C", money.New(1, money.USD))
+	if entries, _ = s.Entries("A"); len(entries) != 1 || entries[0].Postings[0].Account != "A" {
+		t.Errorf("Expected store to be unchanged got %+v", entries)
+	}
+}
@@ -0,0 +1,138 @@
+package ledger
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Store keeps the accounts and entries of a Ledger. The Ledger validates
+// accounts and entries before passing them to the Store.
+//
+// Implementations must be safe for concurrent use if the Ledger is.
+type Store interface {
+	// CreateAccount adds a, or returns ErrDuplicateAccount if its code is in use.
+	CreateAccount(a Account) error
+	// Account returns the account with the given code, or ErrUnknownAccount.
+	Account(code string) (Account, error)
+	// Accounts returns all accounts sorted by code.
+	Accounts() ([]Account, error)
+	// AppendEntry records e and returns its ID. IDs start at 1 and increase
+	// in the order entries are appended.
+	AppendEntry(e Entry) (int64, error)
+	// Entries returns the entries with a posting to the account with the
+	// given code, or all entries if code is empty, in the order they were appended.
+	Entries(code string) ([]Entry, error)
+}
+
+// MemoryStore is a Store keeping accounts and entries in memory.
+// It is safe for concurrent use.
+type MemoryStore struct {
+	mu        sync.RWMutex
+	accounts  map[string]Account
+	entries   []Entry
+	byAccount map[string][]int
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		accounts:  make(map[string]Account),
+		byAccount: make(map[string][]int),
+	}
+}
+
+// CreateAccount implements Store.
+func (s *MemoryStore) CreateAccount(a Account) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.accounts[a.Code]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateAccount, a.Code)
+	}
+
+	s.accounts[a.Code] = a
+	return nil
+}
+
+// Account implements Store.
+func (s *MemoryStore) Account(code string) (Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	a, ok := s.accounts[code]
+	if !ok {
+		return Account{}, fmt.Errorf("%w: %s", ErrUnknownAccount, code)
+	}
+
+	return a, nil
+}
+
+// Accounts implements Store.
+func (s *MemoryStore) Accounts() ([]Account, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]Account, 0, len(s.accounts))
+	for _, a := range s.accounts {
+		res = append(res, a)
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].Code < res[j].Code })
+
+	return res, nil
+}
+
+// AppendEntry implements Store.
+func (s *MemoryStore) AppendEntry(e Entry) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := len(s.entries)
+	e.ID = int64(i + 1)
+	e.Postings = copyPostings(e.Postings)
+	s.entries = append(s.entries, e)
+
+	seen := make(map[string]bool, len(e.Postings))
+	for _, p := range e.Postings {
+		if !seen[p.Account] {
+			seen[p.Account] = true
+			s.byAccount[p.Account] = append(s.byAccount[p.Account], i)
+		}
+	}
+
+	return e.ID, nil
+}
+
+// Entries implements Store.
+func (s *MemoryStore) Entries(code string) ([]Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if code == "" {
+		res := make([]Entry, len(s.entries))
+		for i, e := range s.entries {
+			e.Postings = copyPostings(e.Postings)
+			res[i] = e
+		}
+		return res, nil
+	}
+
+	idx := s.byAccount[code]
+	res := make([]Entry, len(idx))
+	for i, j := range idx {
+		e := s.entries[j]
+		e.Postings = copyPostings(e.Postings)
+		res[i] = e
+	}
+
+	return res, nil
+}
+
+func copyPostings(ps []Posting) []Posting {
+	res := make([]Posting, len(ps))
+	copy(res, ps)
+
+	return res
+}
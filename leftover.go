@@ -0,0 +1,150 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sort"
+)
+
+type leftoverKind int
+
+const (
+	leftoverRoundRobin leftoverKind = iota
+	leftoverLargestRemainder
+	leftoverToLast
+	leftoverToLargest
+	leftoverRandom
+	leftoverToIndex
+)
+
+// Leftover tells AllocateWith and SplitWith how to distribute the pennies left
+// over after dividing Money in proportion to the ratios of the parties.
+type Leftover struct {
+	kind  leftoverKind
+	seed  int64
+	index int
+}
+
+var (
+	// LeftoverRoundRobin gives one leftover penny to each party from the first
+	// one, as Allocate and Split do.
+	LeftoverRoundRobin = Leftover{kind: leftoverRoundRobin}
+
+	// LeftoverLargestRemainder gives one leftover penny to each party with the
+	// largest remainder of the division, so that every party gets its exact share
+	// rounded up or down. This is the largest remainder (Hamilton) method; ties
+	// go to the party listed first. Parties with a zero ratio never get a penny.
+	LeftoverLargestRemainder = Leftover{kind: leftoverLargestRemainder}
+
+	// LeftoverToLast gives all leftover pennies to the last party with a non-zero ratio.
+	LeftoverToLast = Leftover{kind: leftoverToLast}
+
+	// LeftoverToLargest gives all leftover pennies to the party with the largest
+	// ratio, the first one if several share it.
+	LeftoverToLargest = Leftover{kind: leftoverToLargest}
+)
+
+// LeftoverRandom gives one leftover penny to each of randomly picked parties
+// with a non-zero ratio. The same seed always picks the same parties.
+func LeftoverRandom(seed int64) Leftover {
+	return Leftover{kind: leftoverRandom, seed: seed}
+}
+
+// LeftoverToIndex gives all leftover pennies to the party at index i.
+func LeftoverToIndex(i int) Leftover {
+	return Leftover{kind: leftoverToIndex, index: i}
+}
+
+// distribute adds the pennies left over after allocating amount to ms in
+// ratios rs, which sum to sum, to the parties in ms.
+func (l Leftover) distribute(amount Amount, ms []*Money, rs []*big.Int, sum *big.Int) error {
+	var total Amount
+	for _, p := range ms {
+		total += p.amount
+	}
+
+	lo := amount - total
+	sub := int64(1)
+	if lo < 0 {
+		sub = -sub
+	}
+
+	switch l.kind {
+	case leftoverRoundRobin:
+		for p := 0; lo != 0; p++ {
+			ms[p].amount = mutate.calc.add(ms[p].amount, sub)
+			lo -= sub
+		}
+	case leftoverLargestRemainder:
+		rems := remainders(amount, rs, sum)
+		order := make([]int, len(ms))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(i, j int) bool {
+			return rems[order[i]].Cmp(rems[order[j]]) > 0
+		})
+		for _, p := range order {
+			if lo == 0 {
+				break
+			}
+			ms[p].amount = mutate.calc.add(ms[p].amount, sub)
+			lo -= sub
+		}
+	case leftoverToLast:
+		for p := len(ms) - 1; p >= 0; p-- {
+			if rs[p].Sign() > 0 {
+				ms[p].amount = mutate.calc.add(ms[p].amount, lo)
+				break
+			}
+		}
+	case leftoverToLargest:
+		largest := 0
+		for p, r := range rs {
+			if r.Cmp(rs[largest]) > 0 {
+				largest = p
+			}
+		}
+		ms[largest].amount = mutate.calc.add(ms[largest].amount, lo)
+	case leftoverRandom:
+		var parties []int
+		for p, r := range rs {
+			if r.Sign() > 0 {
+				parties = append(parties, p)
+			}
+		}
+		rnd := rand.New(rand.NewSource(l.seed))
+		for _, i := range rnd.Perm(len(parties)) {
+			if lo == 0 {
+				break
+			}
+			ms[parties[i]].amount = mutate.calc.add(ms[parties[i]].amount, sub)
+			lo -= sub
+		}
+	case leftoverToIndex:
+		if l.index < 0 || l.index >= len(ms) {
+			return fmt.Errorf("leftover index %d out of range for %d parties", l.index, len(ms))
+		}
+		ms[l.index].amount = mutate.calc.add(ms[l.index].amount, lo)
+	default:
+		return fmt.Errorf("unknown leftover strategy %d", l.kind)
+	}
+
+	return nil
+}
+
+// remainders returns the absolute remainders of dividing amount times each of
+// rs by sum.
+func remainders(amount Amount, rs []*big.Int, sum *big.Int) []*big.Int {
+	a := big.NewInt(amount)
+	a.Abs(a)
+
+	res := make([]*big.Int, len(rs))
+	for i, r := range rs {
+		res[i] = new(big.Int).Mul(a, r)
+		res[i].Rem(res[i], sum)
+	}
+
+	return res
+}
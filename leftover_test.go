@@ -0,0 +1,113 @@
+package money
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMoney_AllocateWith(t *testing.T) {
+	tcs := []struct {
+		name     string
+		leftover Leftover
+		amount   int64
+		ratios   []int
+		expected []int64
+	}{
+		{"round robin", LeftoverRoundRobin, 100, []int{1, 1, 1000}, []int64{1, 0, 99}},
+		{"largest remainder", LeftoverLargestRemainder, 100, []int{1, 1, 1000}, []int64{0, 0, 100}},
+		{"largest remainder fund", LeftoverLargestRemainder, 1000, []int{2, 3, 5, 7}, []int64{118, 176, 294, 412}},
+		{"largest remainder ties", LeftoverLargestRemainder, 100, []int{30, 30, 30}, []int64{34, 33, 33}},
+		{"largest remainder negative", LeftoverLargestRemainder, -1000, []int{2, 3, 5, 7}, []int64{-118, -176, -294, -412}},
+		{"largest remainder zero ratio", LeftoverLargestRemainder, 10, []int{0, 1, 2}, []int64{0, 3, 7}},
+		{"to last", LeftoverToLast, 100, []int{1, 1, 1}, []int64{33, 33, 34}},
+		{"to last skips zero ratios", LeftoverToLast, 100, []int{1, 1, 1, 0}, []int64{33, 33, 34, 0}},
+		{"to largest", LeftoverToLargest, 1000, []int{2, 7, 5, 7}, []int64{95, 334, 238, 333}},
+		{"to index", LeftoverToIndex(1), -100, []int{1, 1, 1}, []int64{-33, -34, -33}},
+		{"no leftover", LeftoverToIndex(0), 100, []int{1, 1}, []int64{50, 50}},
+		{"zero ratios", LeftoverLargestRemainder, 10, []int{0, 0}, []int64{0, 0}},
+	}
+
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			parties, err := New(tc.amount, EUR).AllocateWith(tc.leftover, tc.ratios...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var rs []int64
+			for _, party := range parties {
+				rs = append(rs, party.amount)
+			}
+
+			if !reflect.DeepEqual(tc.expected, rs) {
+				t.Errorf("Expected allocation of %d for ratios %v to be %v got %v", tc.amount, tc.ratios, tc.expected, rs)
+			}
+		})
+	}
+}
+
+func TestMoney_AllocateWith_Random(t *testing.T) {
+	m := New(1001, EUR)
+	ratios := []int{1, 0, 1, 1, 1, 1, 1, 1, 1, 1, 1}
+
+	first, err := m.AllocateWith(LeftoverRandom(42), ratios...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := m.AllocateWith(LeftoverRandom(42), ratios...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var total int64
+	for i, party := range first {
+		total += party.amount
+		if party.amount != again[i].amount {
+			t.Errorf("Expected the same seed to allocate the same, party %d got %d and %d", i, party.amount, again[i].amount)
+		}
+		if party.amount != 100 && party.amount != 101 && i != 1 {
+			t.Errorf("Expected party %d to get 100 or 101 got %d", i, party.amount)
+		}
+	}
+	if total != 1001 || first[1].amount != 0 {
+		t.Errorf("Expected 1001 in total and nothing for the zero ratio got %d and %d", total, first[1].amount)
+	}
+}
+
+func TestMoney_SplitWith(t *testing.T) {
+	tcs := []struct {
+		leftover Leftover
+		amount   int64
+		n        int
+		expected []int64
+	}{
+		{LeftoverRoundRobin, 5, 3, []int64{2, 2, 1}},
+		{LeftoverLargestRemainder, 5, 3, []int64{2, 2, 1}},
+		{LeftoverToLast, 5, 3, []int64{1, 1, 3}},
+		{LeftoverToLargest, 5, 3, []int64{3, 1, 1}},
+		{LeftoverToIndex(1), -101, 4, []int64{-25, -26, -25, -25}},
+	}
+
+	for _, tc := range tcs {
+		split, err := New(tc.amount, EUR).SplitWith(tc.n, tc.leftover)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var rs []int64
+		for _, party := range split {
+			rs = append(rs, party.amount)
+		}
+
+		if !reflect.DeepEqual(tc.expected, rs) {
+			t.Errorf("Expected split of %d to be %v got %v", tc.amount, tc.expected, rs)
+		}
+	}
+
+	if _, err := New(100, EUR).SplitWith(3, LeftoverToIndex(3)); err == nil {
+		t.Error("Expected err for index out of range")
+	}
+	if _, err := New(100, EUR).AllocateWith(Leftover{kind: -1}, 1, 2); err == nil {
+		t.Error("Expected err for unknown strategy")
+	}
+}
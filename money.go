@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"math/big"
 )
 
 // Injection points for backward compatibility.
@@ -13,6 +14,10 @@ import (
 //
 //	money.UnmarshalJSON = func (m *Money, b []byte) error { ... }
 //	money.MarshalJSON = func (m Money) ([]byte, error) { ... }
+//
+// Overwriting them affects every Money in the program. To use a different JSON
+// shape for some values only, use a JSONCodec or one of the wrapper types such
+// as MajorUnitsJSON instead.
 var (
 	// UnmarshalJSON is injection point of json.Unmarshaller for money.Money
 	UnmarshalJSON = defaultUnmarshalJSON
@@ -24,28 +29,36 @@ var (
 
 	// ErrInvalidJSONUnmarshal happens when the default money.UnmarshalJSON fails to unmarshal Money because of invalid data.
 	ErrInvalidJSONUnmarshal = errors.New("invalid json unmarshal")
+
+	// ErrUnknownCurrency happens when a decoded currency code is not registered in the currencies list.
+	ErrUnknownCurrency = errors.New("unknown currency")
+
+	// ErrAmountOverflow happens when the result of an operation does not fit into an Amount.
+	ErrAmountOverflow = errors.New("amount overflows Amount")
 )
 
 func defaultUnmarshalJSON(m *Money, b []byte) error {
-	data := make(map[string]interface{})
+	var data struct {
+		Amount   json.RawMessage `json:"amount"`
+		Currency json.RawMessage `json:"currency"`
+	}
 	err := json.Unmarshal(b, &data)
 	if err != nil {
 		return err
 	}
 
-	var amount float64
-	if amountRaw, ok := data["amount"]; ok {
-		amount, ok = amountRaw.(float64)
-		if !ok {
-			return ErrInvalidJSONUnmarshal
+	var amount Amount
+	if data.Amount != nil {
+		amount, err = decodeMinorUnits(data.Amount)
+		if err != nil {
+			return err
 		}
 	}
 
 	var currency string
-	if currencyRaw, ok := data["currency"]; ok {
-		currency, ok = currencyRaw.(string)
-		if !ok {
-			return ErrInvalidJSONUnmarshal
+	if data.Currency != nil {
+		if string(data.Currency) == "null" || json.Unmarshal(data.Currency, &currency) != nil {
+			return fmt.Errorf("%w: currency %s is not a string", ErrInvalidJSONUnmarshal, data.Currency)
 		}
 	}
 
@@ -53,7 +66,7 @@ func defaultUnmarshalJSON(m *Money, b []byte) error {
 	if amount == 0 && currency == "" {
 		ref = &Money{}
 	} else {
-		ref = New(int64(amount), currency)
+		ref = New(amount, currency)
 	}
 
 	*m = *ref
@@ -261,29 +274,29 @@ func (m *Money) Round() *Money {
 // Split returns slice of Money structs with split Self value in given number.
 // After division leftover pennies will be distributed round-robin amongst the parties.
 // This means that parties listed first will likely receive more pennies than ones that are listed later.
+// Use SplitWith to distribute them differently.
 func (m *Money) Split(n int) ([]*Money, error) {
+	return m.SplitWith(n, LeftoverRoundRobin)
+}
+
+// SplitWith returns slice of Money structs with split Self value in given number,
+// distributing leftover pennies amongst the parties with l.
+func (m *Money) SplitWith(n int, l Leftover) ([]*Money, error) {
 	if n <= 0 {
 		return nil, errors.New("split must be higher than zero")
 	}
 
 	a := mutate.calc.divide(m.amount, int64(n))
 	ms := make([]*Money, n)
+	rs := make([]*big.Int, n)
 
 	for i := 0; i < n; i++ {
 		ms[i] = &Money{amount: a, currency: m.currency}
+		rs[i] = big.NewInt(1)
 	}
 
-	r := mutate.calc.modulus(m.amount, int64(n))
-	l := mutate.calc.absolute(r)
-	// Add leftovers to the first parties.
-
-	v := int64(1)
-	if m.amount < 0 {
-		v = -1
-	}
-	for p := 0; l != 0; p++ {
-		ms[p].amount = mutate.calc.add(ms[p].amount, v)
-		l--
+	if err := l.distribute(m.amount, ms, rs, big.NewInt(int64(n))); err != nil {
+		return nil, err
 	}
 
 	return ms, nil
@@ -292,14 +305,22 @@ func (m *Money) Split(n int) ([]*Money, error) {
 // Allocate returns slice of Money structs with split Self value in given ratios.
 // It lets split money by given ratios without losing pennies and as Split operations distributes
 // leftover pennies amongst the parties with round-robin principle.
+// Use AllocateWith to distribute them differently.
 func (m *Money) Allocate(rs ...int) ([]*Money, error) {
+	return m.AllocateWith(LeftoverRoundRobin, rs...)
+}
+
+// AllocateWith returns slice of Money structs with split Self value in given ratios,
+// distributing leftover pennies amongst the parties with l.
+func (m *Money) AllocateWith(l Leftover, rs ...int) ([]*Money, error) {
 	if len(rs) == 0 {
 		return nil, errors.New("no ratios specified")
 	}
 
 	// Calculate sum of ratios.
 	var sum int64
-	for _, r := range rs {
+	ratios := make([]*big.Int, len(rs))
+	for i, r := range rs {
 		if r < 0 {
 			return nil, errors.New("negative ratios not allowed")
 		}
@@ -307,39 +328,10 @@ func (m *Money) Allocate(rs ...int) ([]*Money, error) {
 			return nil, errors.New("sum of given ratios exceeds max int")
 		}
 		sum += int64(r)
+		ratios[i] = big.NewInt(int64(r))
 	}
 
-	var total int64
-	ms := make([]*Money, 0, len(rs))
-	for _, r := range rs {
-		party := &Money{
-			amount:   mutate.calc.allocate(m.amount, int64(r), sum),
-			currency: m.currency,
-		}
-
-		ms = append(ms, party)
-		total += party.amount
-	}
-
-	// if the sum of all ratios is zero, then we just returns zeros and don't do anything
-	// with the leftover
-	if sum == 0 {
-		return ms, nil
-	}
-
-	// Calculate leftover value and divide to first parties.
-	lo := m.amount - total
-	sub := int64(1)
-	if lo < 0 {
-		sub = -sub
-	}
-
-	for p := 0; lo != 0; p++ {
-		ms[p].amount = mutate.calc.add(ms[p].amount, sub)
-		lo -= sub
-	}
-
-	return ms, nil
+	return m.allocate(l, ratios, big.NewInt(sum))
 }
 
 // Display lets represent Money struct as string in given Currency value.
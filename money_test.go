@@ -931,6 +931,111 @@ func TestDefaultUnmarshal(t *testing.T) {
 	}
 }
 
+func TestDefaultUnmarshal_Lossless(t *testing.T) {
+	tcs := []struct {
+		given    string
+		expected int64
+		err      string
+	}{
+		{given: `{"amount": 9007199254740993, "currency": "USD"}`, expected: 9007199254740993},
+		{given: `{"amount": 9223372036854775807, "currency": "USD"}`, expected: math.MaxInt64},
+		{given: `{"amount": -9223372036854775808, "currency": "USD"}`, expected: math.MinInt64},
+		{given: `{"amount": 12.0, "currency": "USD"}`, expected: 12},
+		{given: `{"amount": 1e3, "currency": "USD"}`, expected: 1000},
+		{given: `{"amount": 12.7, "currency": "USD"}`, err: "amount 12.7 is not an integer number of minor units"},
+		{given: `{"amount": 9223372036854775808, "currency": "USD"}`, err: "amount 9223372036854775808 overflows Amount"},
+		{given: `{"amount": 1000e-3, "currency": "USD"}`, expected: 1},
+		{given: `{"amount": 1e999999, "currency": "USD"}`, err: "amount 1e999999 overflows Amount"},
+		{given: `{"amount": 1e-999999, "currency": "USD"}`, err: "amount 1e-999999 is not an integer number of minor units"},
+		{given: `{"amount": null, "currency": "USD"}`, err: "amount null is not a number"},
+		{given: `{"amount": 1, "currency": null}`, err: "currency null is not a string"},
+	}
+
+	for _, tc := range tcs {
+		var m Money
+		err := defaultUnmarshalJSON(&m, []byte(tc.given))
+		if tc.err != "" {
+			if !errors.Is(err, ErrInvalidJSONUnmarshal) || err.Error() != ErrInvalidJSONUnmarshal.Error()+": "+tc.err {
+				t.Errorf("Expected error %q, got %v", tc.err, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("Unmarshal(%s) error: %v", tc.given, err)
+			continue
+		}
+
+		if m.Amount() != tc.expected {
+			t.Errorf("Expected %d got %d", tc.expected, m.Amount())
+		}
+	}
+}
+
+func TestKnownCurrencyJSONCodec(t *testing.T) {
+	codec := KnownCurrencyJSONCodec(DefaultJSONCodec)
+
+	var m Money
+	if err := codec.Unmarshal(&m, []byte(`{"amount": 10012, "currency": "USD"}`)); err != nil {
+		t.Fatal(err)
+	}
+	if m.Display() != "$100.12" {
+		t.Errorf("Expected %s got %s", "$100.12", m.Display())
+	}
+
+	if err := codec.Unmarshal(&m, []byte(`{}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	err := codec.Unmarshal(&m, []byte(`{"amount": 10012, "currency": "XYZ"}`))
+	if !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("Expected ErrUnknownCurrency, got %v", err)
+	}
+
+	err = DefaultJSONCodec.Unmarshal(&m, []byte(`{"amount": 10012, "currency": "XYZ"}`))
+	if err != nil {
+		t.Errorf("Expected no error without KnownCurrencyJSONCodec, got %v", err)
+	}
+}
+
+// mapUnmarshalJSON is the map-based decoding defaultUnmarshalJSON used to do,
+// kept to compare allocations against in BenchmarkDefaultUnmarshal.
+func mapUnmarshalJSON(m *Money, b []byte) error {
+	data := make(map[string]interface{})
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+
+	amount, _ := data["amount"].(float64)
+	currency, _ := data["currency"].(string)
+	*m = *New(int64(amount), currency)
+	return nil
+}
+
+func BenchmarkDefaultUnmarshal(b *testing.B) {
+	given := []byte(`{"amount": 123456789, "currency": "USD"}`)
+
+	b.Run("raw", func(b *testing.B) {
+		b.ReportAllocs()
+		var m Money
+		for i := 0; i < b.N; i++ {
+			if err := defaultUnmarshalJSON(&m, given); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("map", func(b *testing.B) {
+		b.ReportAllocs()
+		var m Money
+		for i := 0; i < b.N; i++ {
+			if err := mapUnmarshalJSON(&m, given); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func TestCustomUnmarshal(t *testing.T) {
 	given := `{"amount": 10012, "currency_code":"USD", "currency_fraction":2}`
 	expected := "$100.12"
@@ -0,0 +1,169 @@
+// Package moneycsv streams [money.Money] values in and out of CSV files with
+// separate amount and currency columns.
+//
+// A [Reader] decodes one row at a time, so memory use does not grow with the
+// size of the input. Rows that cannot be decoded are reported as [*RowError]
+// values carrying their line number, and reading can continue with the next row:
+//
+//	r := moneycsv.NewReader(f, moneycsv.WithHeader("amount", "currency"))
+//	for {
+//		rec, err := r.Read()
+//		if err == io.EOF {
+//			break
+//		}
+//		var rowErr *moneycsv.RowError
+//		if errors.As(err, &rowErr) {
+//			log.Printf("skipping row: %v", rowErr)
+//			continue
+//		}
+//		if err != nil {
+//			return err
+//		}
+//		process(rec.Money)
+//	}
+//
+// By default amounts are read and written in major units with the decimal
+// separator of their currency ("12.34", or "12,34" for DKK), parsed with a
+// [parser.AmountParser]. Use [WithFormat] to read and write minor units instead.
+package moneycsv
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Rhymond/go-money/parser"
+)
+
+var (
+	// ErrMissingColumn is returned when a row or header lacks a mapped column.
+	ErrMissingColumn = errors.New("missing column")
+	// ErrInvalidAmount is returned when an amount field in [MinorUnits] is not an integer.
+	// Amounts in [MajorUnits] report the error of the [parser.Parser] instead.
+	ErrInvalidAmount = errors.New("invalid amount")
+	// ErrInvalidCurrency is returned when a currency field is not a known currency.
+	ErrInvalidCurrency = errors.New("invalid currency")
+	// ErrInvalidColumn is returned by a [Reader] or [Writer] created with a negative column index.
+	ErrInvalidColumn = errors.New("invalid column")
+)
+
+// RowError describes a row that could not be decoded or encoded.
+type RowError struct {
+	// Line is the 1-based line on which the row starts.
+	Line int
+	// Column is the 1-based column of the offending field, or 0 if not tied to a field.
+	Column int
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *RowError) Error() string {
+	if e.Column == 0 {
+		return fmt.Sprintf("moneycsv: line %d: %v", e.Line, e.Err)
+	}
+	return fmt.Sprintf("moneycsv: line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// Format selects how amounts are represented in a CSV field.
+type Format int
+
+const (
+	// MajorUnits represents amounts as decimal strings in major units, e.g. "12.34".
+	MajorUnits Format = iota
+	// MinorUnits represents amounts as integers in minor units, e.g. "1234".
+	MinorUnits
+)
+
+// Option applies a modification to [Options] and returns it.
+type Option func(o *Options) *Options
+
+// WithColumns maps the amount and currency to the given 0-based column indexes.
+func WithColumns(amount, currency int) Option {
+	return func(o *Options) *Options {
+		o.AmountColumn = amount
+		o.CurrencyColumn = currency
+		return o
+	}
+}
+
+// WithHeader maps the amount and currency to the columns with the given names
+// in the header row. A [Reader] resolves the column indexes from the first row
+// it reads, and [Writer.WriteHeader] writes the names into the mapped columns.
+func WithHeader(amount, currency string) Option {
+	return func(o *Options) *Options {
+		o.AmountHeader = amount
+		o.CurrencyHeader = currency
+		return o
+	}
+}
+
+// WithFormat sets how amounts are represented in a CSV field.
+func WithFormat(f Format) Option {
+	return func(o *Options) *Options {
+		o.Format = f
+		return o
+	}
+}
+
+// WithParser sets the [parser.Parser] used to read amounts in [MajorUnits].
+func WithParser(p parser.Parser) Option {
+	return func(o *Options) *Options {
+		o.Parser = p
+		return o
+	}
+}
+
+// WithComma sets the field delimiter.
+func WithComma(r rune) Option {
+	return func(o *Options) *Options {
+		o.Comma = r
+		return o
+	}
+}
+
+// Options configures a [Reader] or [Writer].
+type Options struct {
+	AmountColumn   int
+	CurrencyColumn int
+	AmountHeader   string
+	CurrencyHeader string
+	Format         Format
+	Parser         parser.Parser
+	Comma          rune
+}
+
+// DefaultOptions returns [Options] with
+//
+// AmountColumn=0, CurrencyColumn=1, no header, Format=MajorUnits,
+// a default [parser.AmountParser] and Comma=','.
+func DefaultOptions() *Options {
+	return &Options{
+		AmountColumn:   0,
+		CurrencyColumn: 1,
+		Format:         MajorUnits,
+		Parser:         parser.NewAmountParser(),
+		Comma:          ',',
+	}
+}
+
+// checkColumns returns ErrInvalidColumn if a mapped column index is negative.
+func (o *Options) checkColumns() error {
+	if o.AmountColumn < 0 || o.CurrencyColumn < 0 {
+		return fmt.Errorf("moneycsv: %w: amount %d, currency %d", ErrInvalidColumn, o.AmountColumn, o.CurrencyColumn)
+	}
+
+	return nil
+}
+
+func newOptions(opts []Option) *Options {
+	opt := DefaultOptions()
+	for _, o := range opts {
+		opt = o(opt)
+	}
+
+	return opt
+}
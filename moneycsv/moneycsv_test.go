@@ -0,0 +1,187 @@
+package moneycsv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+	"github.com/Rhymond/go-money/parser"
+)
+
+func TestReader_Read(t *testing.T) {
+	in := "id,currency,amount\n" +
+		"1,USD,\"1,234.56\"\n" +
+		"2,EUR,12x\n" +
+		"3,ZZZ,1.00\n" +
+		"4,JPY\n" +
+		"5,\"EUR\"x,1\n" +
+		"6,978,-0.5\n"
+
+	r := NewReader(strings.NewReader(in), WithHeader("amount", "currency"))
+
+	type result struct {
+		line   int
+		id     string
+		amount int64
+		code   string
+		err    error
+	}
+	want := []result{
+		{line: 2, id: "1", amount: 123456, code: money.USD},
+		{line: 3, err: parser.ErrBadChar},
+		{line: 4, err: ErrInvalidCurrency},
+		{line: 5, err: ErrMissingColumn},
+		{line: 6, err: csv.ErrQuote},
+		{line: 7, id: "6", amount: -50, code: money.EUR},
+	}
+
+	for i, w := range want {
+		rec, err := r.Read()
+		if w.err != nil {
+			var rowErr *RowError
+			if !errors.As(err, &rowErr) {
+				t.Fatalf("row %d: error = %v, want *RowError", i, err)
+			}
+			if rowErr.Line != w.line {
+				t.Errorf("row %d: error line = %d, want %d", i, rowErr.Line, w.line)
+			}
+			if !errors.Is(err, w.err) {
+				t.Errorf("row %d: error = %v, want errors.Is(...,%v)", i, err, w.err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("row %d: unexpected error: %v", i, err)
+		}
+		if rec.Line != w.line || rec.Fields[0] != w.id {
+			t.Errorf("row %d: got line %d id %q, want line %d id %q", i, rec.Line, rec.Fields[0], w.line, w.id)
+		}
+		if rec.Money.Amount() != w.amount || rec.Money.Currency().Code != w.code {
+			t.Errorf("row %d: got %d %s, want %d %s", i, rec.Money.Amount(), rec.Money.Currency().Code, w.amount, w.code)
+		}
+	}
+
+	if _, err := r.Read(); err != io.EOF {
+		t.Fatalf("error = %v, want io.EOF", err)
+	}
+}
+
+func TestReader_MissingHeaderColumn(t *testing.T) {
+	r := NewReader(strings.NewReader("amount,ccy\n1,USD\n"), WithHeader("amount", "currency"))
+
+	for i := 0; i < 2; i++ {
+		if _, err := r.Read(); !errors.Is(err, ErrMissingColumn) {
+			t.Fatalf("error = %v, want ErrMissingColumn", err)
+		}
+	}
+}
+
+func TestReader_MinorUnits(t *testing.T) {
+	r := NewReader(strings.NewReader("USD;x;1234\nEUR;y;12.5\n"), WithColumns(2, 0), WithFormat(MinorUnits), WithComma(';'))
+
+	rec, err := r.Read()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rec.Money.Amount() != 1234 || rec.Money.Currency().Code != money.USD {
+		t.Errorf("got %d %s, want 1234 USD", rec.Money.Amount(), rec.Money.Currency().Code)
+	}
+
+	var rowErr *RowError
+	if _, err := r.Read(); !errors.Is(err, ErrInvalidAmount) || !errors.As(err, &rowErr) || rowErr.Column != 3 {
+		t.Fatalf("error = %v, want ErrInvalidAmount in column 3", err)
+	}
+}
+
+func TestWriter_Write(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want string
+	}{
+		{
+			name: "major-units",
+			want: "id,currency,amount\n1,USD,1234.56\n2,JPY,-5\n3,EUR,0.07\n",
+		},
+		{
+			name: "minor-units",
+			opts: []Option{WithFormat(MinorUnits)},
+			want: "id,currency,amount\n1,USD,123456\n2,JPY,-5\n3,EUR,7\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			w := NewWriter(&buf, append(tt.opts, WithColumns(2, 1), WithHeader("amount", "currency"))...)
+
+			if err := w.WriteHeader([]string{"id"}); err != nil {
+				t.Fatalf("WriteHeader() error = %v", err)
+			}
+			for i, m := range []*money.Money{money.New(123456, money.USD), money.New(-5, money.JPY), money.New(7, money.EUR)} {
+				if err := w.Write([]string{string(rune('1' + i))}, m); err != nil {
+					t.Fatalf("Write() error = %v", err)
+				}
+			}
+			w.Flush()
+			if err := w.Error(); err != nil {
+				t.Fatalf("Error() = %v", err)
+			}
+
+			if got := buf.String(); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, m := range []*money.Money{money.New(100000000, money.EUR), money.New(-1, money.BHD), money.New(1250, money.DKK)} {
+		if err := w.Write(nil, m); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+	w.Flush()
+	if got := buf.String(); !strings.Contains(got, "\"12,50\",DKK\n") {
+		t.Errorf("got %q, want DKK written as \"12,50\"", got)
+	}
+
+	r := NewReader(&buf)
+	for _, want := range []*money.Money{money.New(100000000, money.EUR), money.New(-1, money.BHD), money.New(1250, money.DKK)} {
+		rec, err := r.Read()
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		if eq, err := rec.Money.Equals(want); err != nil || !eq {
+			t.Errorf("got %d %s, want %d %s", rec.Money.Amount(), rec.Money.Currency().Code, want.Amount(), want.Currency().Code)
+		}
+	}
+}
+
+func TestNegativeColumns(t *testing.T) {
+	r := NewReader(strings.NewReader("1,USD\n"), WithColumns(-1, 1))
+	for i := 0; i < 2; i++ {
+		if _, err := r.Read(); !errors.Is(err, ErrInvalidColumn) {
+			t.Fatalf("Read() error = %v, want ErrInvalidColumn", err)
+		}
+	}
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf, WithColumns(0, -2))
+	if err := w.WriteHeader(nil); !errors.Is(err, ErrInvalidColumn) {
+		t.Errorf("WriteHeader() error = %v, want ErrInvalidColumn", err)
+	}
+	if err := w.Write(nil, money.New(1, money.USD)); !errors.Is(err, ErrInvalidColumn) {
+		t.Errorf("Write() error = %v, want ErrInvalidColumn", err)
+	}
+	if err := w.Error(); !errors.Is(err, ErrInvalidColumn) {
+		t.Errorf("Error() = %v, want ErrInvalidColumn", err)
+	}
+}
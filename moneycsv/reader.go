@@ -0,0 +1,155 @@
+package moneycsv
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/Rhymond/go-money"
+)
+
+// Record is a decoded CSV row.
+type Record struct {
+	// Line is the 1-based line on which the row starts.
+	Line int
+	// Fields holds all fields of the row. It is reused by the next call to
+	// [Reader.Read], so copy it if it must outlive that call.
+	Fields []string
+	// Money is the decoded amount and currency.
+	Money *money.Money
+}
+
+// Reader decodes Money from the rows of a CSV input.
+type Reader struct {
+	r   *csv.Reader
+	opt Options
+
+	headerDone bool
+	err        error
+}
+
+// NewReader returns a new [Reader] reading from r with the given options.
+// If the mapped columns are invalid, every read returns [ErrInvalidColumn].
+func NewReader(r io.Reader, opts ...Option) *Reader {
+	opt := newOptions(opts)
+
+	cr := csv.NewReader(r)
+	cr.Comma = opt.Comma
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+
+	rd := &Reader{r: cr, opt: *opt, headerDone: opt.AmountHeader == "" && opt.CurrencyHeader == ""}
+	if rd.headerDone {
+		// otherwise the columns are taken from the header
+		rd.err = opt.checkColumns()
+	}
+
+	return rd
+}
+
+// Read decodes the next row. It returns io.EOF at the end of the input.
+//
+// A row that cannot be decoded is reported as a [*RowError] and does not stop
+// the Reader; the next call to Read continues with the following row. Any other
+// error, such as a failure of the underlying reader or a header without the
+// mapped columns, is returned by every subsequent call.
+func (r *Reader) Read() (*Record, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if !r.headerDone {
+		if err := r.readHeader(); err != nil {
+			r.err = err
+			return nil, err
+		}
+	}
+
+	fields, err := r.r.Read()
+	if err != nil {
+		var pe *csv.ParseError
+		if errors.As(err, &pe) {
+			return nil, &RowError{Line: pe.StartLine, Column: pe.Column, Err: pe.Err}
+		}
+		r.err = err
+		return nil, err
+	}
+
+	line, _ := r.r.FieldPos(0)
+	m, err := r.decode(fields, line)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Record{Line: line, Fields: fields, Money: m}, nil
+}
+
+func (r *Reader) readHeader() error {
+	header, err := r.r.Read()
+	if err != nil {
+		if err == io.EOF {
+			return err
+		}
+		return fmt.Errorf("moneycsv: reading header: %w", err)
+	}
+	r.headerDone = true
+
+	r.opt.AmountColumn, r.opt.CurrencyColumn = -1, -1
+	for i, h := range header {
+		switch strings.TrimSpace(h) {
+		case r.opt.AmountHeader:
+			r.opt.AmountColumn = i
+		case r.opt.CurrencyHeader:
+			r.opt.CurrencyColumn = i
+		}
+	}
+
+	if r.opt.AmountColumn < 0 {
+		return fmt.Errorf("moneycsv: header: %w: %q", ErrMissingColumn, r.opt.AmountHeader)
+	}
+	if r.opt.CurrencyColumn < 0 {
+		return fmt.Errorf("moneycsv: header: %w: %q", ErrMissingColumn, r.opt.CurrencyHeader)
+	}
+
+	return nil
+}
+
+func (r *Reader) decode(fields []string, line int) (*money.Money, error) {
+	ai, ci := r.opt.AmountColumn, r.opt.CurrencyColumn
+	if ai >= len(fields) {
+		return nil, &RowError{Line: line, Column: ai + 1, Err: ErrMissingColumn}
+	}
+	if ci >= len(fields) {
+		return nil, &RowError{Line: line, Column: ci + 1, Err: ErrMissingColumn}
+	}
+
+	code := strings.TrimSpace(fields[ci])
+	c := money.GetCurrency(code)
+	if c == nil {
+		c = money.GetCurrencyByNumericCode(code)
+	}
+	if c == nil {
+		return nil, &RowError{Line: line, Column: ci + 1, Err: fmt.Errorf("%w: %q", ErrInvalidCurrency, code)}
+	}
+
+	var amount money.Amount
+	switch r.opt.Format {
+	case MinorUnits:
+		a, err := strconv.ParseInt(strings.TrimSpace(fields[ai]), 10, 64)
+		if err != nil {
+			return nil, &RowError{Line: line, Column: ai + 1, Err: fmt.Errorf("%w: %v", ErrInvalidAmount, err)}
+		}
+		amount = a
+	default:
+		a, err := r.opt.Parser.Parse(fields[ai], c.Code)
+		if err != nil {
+			return nil, &RowError{Line: line, Column: ai + 1, Err: err}
+		}
+		amount = a
+	}
+
+	return money.New(amount, c.Code), nil
+}
@@ -0,0 +1,107 @@
+package moneycsv
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/Rhymond/go-money"
+)
+
+// Writer encodes Money into the rows of a CSV output.
+type Writer struct {
+	w      *csv.Writer
+	opt    Options
+	record []string
+	err    error
+}
+
+// NewWriter returns a new [Writer] writing to w with the given options.
+// If the mapped columns are invalid, every write returns [ErrInvalidColumn].
+func NewWriter(w io.Writer, opts ...Option) *Writer {
+	opt := newOptions(opts)
+
+	cw := csv.NewWriter(w)
+	cw.Comma = opt.Comma
+
+	return &Writer{w: cw, opt: *opt, err: opt.checkColumns()}
+}
+
+// WriteHeader writes a header row made of header with the names given to
+// [WithHeader] placed into the amount and currency columns.
+func (w *Writer) WriteHeader(header []string) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	rec := w.row(header)
+	if w.opt.AmountHeader != "" {
+		rec[w.opt.AmountColumn] = w.opt.AmountHeader
+	}
+	if w.opt.CurrencyHeader != "" {
+		rec[w.opt.CurrencyColumn] = w.opt.CurrencyHeader
+	}
+
+	return w.w.Write(rec)
+}
+
+// Write writes a row made of fields with the amount and currency of m placed
+// into their columns. fields may be shorter than the mapped columns, in which
+// case the row is padded with empty fields. Amounts in [MajorUnits] are written
+// with the decimal separator of the currency, which the default parser of a
+// [Reader] expects, e.g. "12,50" for DKK.
+func (w *Writer) Write(fields []string, m *money.Money) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	rec := w.row(fields)
+
+	c := m.Currency()
+	switch w.opt.Format {
+	case MinorUnits:
+		rec[w.opt.AmountColumn] = strconv.FormatInt(m.Amount(), 10)
+	default:
+		rec[w.opt.AmountColumn] = money.NewFormatter(c.Fraction, c.Decimal, "", "", "1").Format(m.Amount())
+	}
+	rec[w.opt.CurrencyColumn] = c.Code
+
+	return w.w.Write(rec)
+}
+
+// Flush writes any buffered data to the underlying io.Writer.
+// To check if an error occurred during Flush, call [Writer.Error].
+func (w *Writer) Flush() {
+	w.w.Flush()
+}
+
+// Error reports any error that has occurred during a previous Write or Flush.
+func (w *Writer) Error() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	return w.w.Error()
+}
+
+// row copies fields into the reused record buffer, sized to hold the mapped columns.
+func (w *Writer) row(fields []string) []string {
+	n := len(fields)
+	if w.opt.AmountColumn >= n {
+		n = w.opt.AmountColumn + 1
+	}
+	if w.opt.CurrencyColumn >= n {
+		n = w.opt.CurrencyColumn + 1
+	}
+
+	if cap(w.record) < n {
+		w.record = make([]string, n)
+	}
+	w.record = w.record[:n]
+	copy(w.record, fields)
+	for i := len(fields); i < n; i++ {
+		w.record[i] = ""
+	}
+
+	return w.record
+}
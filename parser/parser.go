@@ -29,11 +29,20 @@
 //
 // Note: If a currency symbol is used in the input string, it must match the
 // currency corresponding to the provided ISO code; otherwise, an error is returned.
+//
+// Amounts that do not fit into a money.Amount are rejected with [ErrOverflow].
+// To parse them, or amounts of currencies with more than 18 fractional digits,
+// use [AmountParser.ParseBig]:
+//
+//	p := parser.NewAmountParser()
+//	wei, err := p.ParseBig("1.000000000000000001", "ETH") // 1000000000000000001
 package parser
 
 import (
 	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"strings"
 	"unicode/utf8"
 
@@ -72,6 +81,8 @@ var (
 	ErrBadChar = errors.New("invalid character")
 	// ErrNoDigits is returned when the input string contains no digits.
 	ErrNoDigits = errors.New("no digits")
+	// ErrOverflow is returned when the parsed amount does not fit into a [money.Amount].
+	ErrOverflow = errors.New("amount overflows money.Amount")
 )
 
 // Parser is the interface for parsing monetary strings.
@@ -81,12 +92,23 @@ type Parser interface {
 	Parse(s, currency string) (money.Amount, error)
 }
 
+// BigParser is the interface for parsing monetary strings into
+// arbitrary-precision amounts of minor units.
+type BigParser interface {
+	// ParseBig parses a string into a [big.Int] of minor units based on the
+	// given ISO or numeric code and input.
+	ParseBig(s, currency string) (*big.Int, error)
+}
+
 // AmountParser is the default implementation of the [Parser] interface.
 type AmountParser struct {
 	opt ParserOptions
 }
 
-var _ Parser = (*AmountParser)(nil)
+var (
+	_ Parser    = (*AmountParser)(nil)
+	_ BigParser = (*AmountParser)(nil)
+)
 
 // NewAmountParser returns a new [AmountParser] with the given options.
 func NewAmountParser(opts ...Option) *AmountParser {
@@ -107,38 +129,126 @@ func NewAmountParser(opts ...Option) *AmountParser {
 
 // Parse parses a string into a [money.Amount] based on the given
 // ISO code and input.
+//
+// Amounts that do not fit into a [money.Amount] are rejected with [ErrOverflow];
+// use [AmountParser.ParseBig] for those.
 func (p *AmountParser) Parse(input string, currency string) (money.Amount, error) {
+	s, c, err := p.prepare(input, currency)
+	if err != nil {
+		return money.AmountZero, err
+	}
+
+	return p.parse(s, *c)
+}
+
+// ParseBig parses a string into an arbitrary-precision amount of minor units
+// based on the given ISO code and input. It accepts the same inputs as
+// [AmountParser.Parse] but is not limited to the range of a [money.Amount],
+// which makes it suitable for large amounts or currencies with many fractional
+// digits, such as tokens with 18 decimals.
+func (p *AmountParser) ParseBig(input string, currency string) (*big.Int, error) {
+	s, c, err := p.prepare(input, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.parseBig(s, *c)
+}
+
+func (p *AmountParser) prepare(input string, currency string) (string, *money.Currency, error) {
 	s := strings.TrimSpace(input)
 	if s == "" {
-		return money.AmountZero, ErrEmptyInput
+		return "", nil, ErrEmptyInput
 	}
 	if currency == "" {
-		return money.AmountZero, ErrInvalidISO
+		return "", nil, ErrInvalidISO
 	}
 
 	q := strings.TrimSpace(currency)
 	c, err := lookupCurrency(q)
 	if err != nil {
-		return money.AmountZero, err
+		return "", nil, err
 	}
 
 	if !p.opt.AcceptSigns && containsSign(s) {
-		return money.AmountZero, fmt.Errorf("input %q: %w", s, ErrSignsNotAllowed)
+		return "", nil, fmt.Errorf("input %q: %w", s, ErrSignsNotAllowed)
 	}
 	if !p.opt.AllowCurrencySymbol && containsCurrencySymbol(s) {
-		return money.AmountZero, fmt.Errorf("input %q: %w", s, ErrCurrencySymbolNotAllowed)
+		return "", nil, fmt.Errorf("input %q: %w", s, ErrCurrencySymbolNotAllowed)
 	}
 
-	return p.parse(s, *c)
+	return s, c, nil
 }
 
 func (p *AmountParser) parse(s string, cur money.Currency) (money.Amount, error) {
+	n, err := p.scan(s, cur)
+	if err != nil {
+		return money.AmountZero, err
+	}
+
+	intVal, err := atoiRunes(n.intDigits)
+	if err != nil {
+		return money.AmountZero, fmt.Errorf("input %q: %w", s, err)
+	}
+	fracVal, err := atoiRunes(n.fracDigits)
+	if err != nil {
+		return money.AmountZero, fmt.Errorf("input %q: %w", s, err)
+	}
+
+	base, err := pow10int64(len(n.fracDigits))
+	if err != nil {
+		return money.AmountZero, fmt.Errorf("input %q: %w", s, err)
+	}
+
+	// the magnitude of a negative amount goes up to that of math.MinInt64
+	limit := uint64(math.MaxInt64)
+	if n.sign < 0 {
+		limit++
+	}
+	if intVal > limit || fracVal > limit || intVal > (limit-fracVal)/uint64(base) {
+		return money.AmountZero, fmt.Errorf("input %q: %w", s, ErrOverflow)
+	}
+	minor := intVal*uint64(base) + fracVal
+
+	if n.sign < 0 {
+		return money.Amount(-int64(minor)), nil
+	}
+	return money.Amount(minor), nil
+}
+
+func (p *AmountParser) parseBig(s string, cur money.Currency) (*big.Int, error) {
+	n, err := p.scan(s, cur)
+	if err != nil {
+		return nil, err
+	}
+
+	digits := string(n.intDigits) + string(n.fracDigits)
+	v, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrBadChar, digits)
+	}
+	if n.sign < 0 {
+		v.Neg(v)
+	}
+
+	return v, nil
+}
+
+// number holds the sign and digits of a scanned input. fracDigits is padded
+// to the fraction of the currency the input was scanned for.
+type number struct {
+	sign       int64
+	intDigits  []rune
+	fracDigits []rune
+}
+
+func (p *AmountParser) scan(s string, cur money.Currency) (number, error) {
 	s = strings.TrimSpace(strings.ReplaceAll(s, nbsp, space))
 
 	if p.opt.AllowCurrencySymbol && len(s) > 0 {
 		currIdx := strings.Index(s, cur.Grapheme)
 		if currIdx == -1 {
-			return money.AmountZero, ErrInvalidCurrencySymbol
+			return number{}, ErrInvalidCurrencySymbol
 		}
 
 		s = strings.Replace(s, cur.Grapheme, "", 1)
@@ -158,7 +268,7 @@ func (p *AmountParser) parse(s string, cur money.Currency) (money.Amount, error)
 	}
 
 	if s == "" {
-		return money.AmountZero, ErrNoDigits
+		return number{}, ErrNoDigits
 	}
 
 	dec := rune('.')
@@ -187,17 +297,17 @@ func (p *AmountParser) parse(s string, cur money.Currency) (money.Amount, error)
 				tmp := lastSeenRune
 				lastSeenRune = r
 				if !hasDec && (tmp != 48 && tmp != lastSeenRune) {
-					return money.AmountZero, fmt.Errorf("input: %s: %w: %c", s, ErrMixedGrouping, r)
+					return number{}, fmt.Errorf("input: %s: %w: %c", s, ErrMixedGrouping, r)
 				}
 			}
 			continue
 		default:
-			return 0, fmt.Errorf("%w: %q", ErrBadChar, r)
+			return number{}, fmt.Errorf("%w: %q", ErrBadChar, r)
 		}
 	}
 
 	if len(intDigits) == 0 && len(fracDigitsRunes) == 0 {
-		return 0, ErrNoDigits
+		return number{}, ErrNoDigits
 	}
 
 	switch {
@@ -206,20 +316,8 @@ func (p *AmountParser) parse(s string, cur money.Currency) (money.Amount, error)
 			fracDigitsRunes = append(fracDigitsRunes, '0')
 		}
 	case len(fracDigitsRunes) > fracDigits:
-		return 0, ErrTooManyDecimals
+		return number{}, ErrTooManyDecimals
 	}
 
-	intVal, err := atoiRunes(intDigits)
-	if err != nil {
-		return 0, err
-	}
-	fracVal, err := atoiRunes(fracDigitsRunes)
-	if err != nil {
-		return 0, err
-	}
-
-	base := pow10int64(fracDigits)
-	minor := intVal*base + fracVal
-
-	return money.Amount(sign * minor), nil
+	return number{sign: sign, intDigits: intDigits, fracDigits: fracDigitsRunes}, nil
 }
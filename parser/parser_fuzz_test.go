@@ -1,6 +1,7 @@
 package parser_test
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/Rhymond/go-money/parser"
@@ -22,6 +23,11 @@ func FuzzParseStringToAmount(f *testing.F) {
 	f.Add("\u043b\u04321,234.55", "BGN", true, false, false)
 	f.Add("€1,234.55", "BGN", false, false, false)
 	f.Add("1 000,234.55", "BGN", false, false, true)
+	f.Add("92,233,720,368,547,758.07", "USD", false, false, false)
+	f.Add("92,233,720,368,547,758.08", "USD", false, false, false)
+	f.Add("-99999999999999999999999999", "JPY", false, true, false)
+	f.Add("1.000000000000000001", "ETH", false, false, false)
+	f.Add("123456789.123456789123456789", "ETH", false, false, false)
 
 	f.Fuzz(func(t *testing.T, s, iso string, currencySymbol, acceptSigns, strictGrouping bool) {
 		var opts []parser.Option
@@ -36,6 +42,21 @@ func FuzzParseStringToAmount(f *testing.F) {
 		}
 
 		p := parser.NewAmountParser(opts...)
-		_, _ = p.Parse(s, iso)
+		got, err := p.Parse(s, iso)
+		gotBig, errBig := p.ParseBig(s, iso)
+
+		switch {
+		case err == nil:
+			if errBig != nil {
+				t.Fatalf("ParseBig(%q,%q) error = %v, Parse returned %d", s, iso, errBig, got)
+			}
+			if !gotBig.IsInt64() || gotBig.Int64() != got {
+				t.Fatalf("ParseBig(%q,%q) = %s, Parse returned %d", s, iso, gotBig, got)
+			}
+		case errors.Is(err, parser.ErrOverflow):
+			if errBig != nil {
+				t.Fatalf("ParseBig(%q,%q) error = %v on overflowing input", s, iso, errBig)
+			}
+		}
 	})
 }
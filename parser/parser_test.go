@@ -2,11 +2,19 @@ package parser
 
 import (
 	"errors"
+	"math"
+	"math/big"
 	"testing"
 
 	"github.com/Rhymond/go-money"
 )
 
+const eth = "ETH"
+
+func init() {
+	money.AddCurrency(eth, "\u039e", "$1", ".", ",", 18)
+}
+
 type tc struct {
 	name string
 	in   string
@@ -60,6 +68,19 @@ func TestParseAmount_Table(t *testing.T) {
 		{name: "err/EUR/only-plus/no-digits", in: "+", iso: money.EUR, opts: []Option{WithAcceptSigns(true)}, err: ErrNoDigits},
 		{name: "err/USD/only-symbol/no-digits", in: "$", iso: money.USD, opts: []Option{WithAllowCurrencySymbol(true)}, err: ErrNoDigits},
 
+		{name: "ok/USD/max-int64", in: "92,233,720,368,547,758.07", iso: money.USD, want: 9223372036854775807},
+		{name: "ok/USD/min-int64+1", in: "-92,233,720,368,547,758.07", iso: money.USD, want: -9223372036854775807},
+		{name: "ok/USD/min-int64", in: "-92,233,720,368,547,758.08", iso: money.USD, want: math.MinInt64},
+		{name: "ok/JPY/min-int64", in: "-9223372036854775808", iso: money.JPY, want: math.MinInt64},
+		{name: "ok/ETH/1-wei", in: "0.000000000000000001", iso: eth, want: 1},
+		{name: "ok/ETH/9.2", in: "9.2", iso: eth, want: 9200000000000000000},
+
+		{name: "err/USD/max-int64+1/overflow", in: "92,233,720,368,547,758.08", iso: money.USD, err: ErrOverflow},
+		{name: "err/USD/min-int64-1/overflow", in: "-92,233,720,368,547,758.09", iso: money.USD, err: ErrOverflow},
+		{name: "err/JPY/max-int64+1/overflow", in: "9223372036854775808", iso: money.JPY, err: ErrOverflow},
+		{name: "err/JPY/20-digits/overflow", in: "99999999999999999999", iso: money.JPY, err: ErrOverflow},
+		{name: "err/ETH/10/overflow", in: "10", iso: eth, err: ErrOverflow},
+
 		{name: "ok/USD/supports-pkg-formatted/USD/1,234,567.89", in: "1,234,567.89 $", iso: money.USD, want: 123456789, opts: []Option{WithAllowCurrencySymbol(true), WithStrictGrouping(true)}},
 		{name: "ok/USD/supports-pkg-formatted/GBP/1,234,567.89", in: "£1,234,567.89", iso: money.GBP, want: 123456789, opts: []Option{WithAllowCurrencySymbol(true), WithStrictGrouping(true)}},
 	}
@@ -111,3 +132,62 @@ func TestParseAmount_RejectsAnyCurrencySymbolWhenNotAllowed(t *testing.T) {
 		t.Fatalf("err = %v, want ErrCurrencySymbolNotAllowed", err)
 	}
 }
+
+func TestParseBig(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		in   string
+		iso  string
+		want string
+		err  error
+	}{
+		{name: "ok/USD/1,234.56", in: "1,234.56", iso: money.USD, want: "123456"},
+		{name: "ok/USD/-1,234.56", in: "-1,234.56", iso: money.USD, want: "-123456"},
+		{name: "ok/USD/max-int64+1", in: "92,233,720,368,547,758.08", iso: money.USD, want: "9223372036854775808"},
+		{name: "ok/JPY/20-digits", in: "99999999999999999999", iso: money.JPY, want: "99999999999999999999"},
+		{name: "ok/ETH/1234.000000000000000001", in: "1234.000000000000000001", iso: eth, want: "1234000000000000000001"},
+		{name: "ok/ETH/-0.5", in: "-0.5", iso: eth, want: "-500000000000000000"},
+
+		{name: "err/ETH/too-many-fraction", in: "0.0000000000000000001", iso: eth, err: ErrTooManyDecimals},
+		{name: "err/USD/bad-char", in: "12a3", iso: money.USD, err: ErrBadChar},
+		{name: "err/empty/empty-input", in: "", iso: money.USD, err: ErrEmptyInput},
+	}
+
+	for _, c := range cases {
+		c := c
+		t.Run(c.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewAmountParser().ParseBig(c.in, c.iso)
+			if c.err != nil {
+				if !errors.Is(err, c.err) {
+					t.Fatalf("ParseBig(%q,%q) error = %v, want errors.Is(...,%v)", c.in, c.iso, err, c.err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseBig(%q,%q) unexpected error: %v", c.in, c.iso, err)
+			}
+			want, _ := new(big.Int).SetString(c.want, 10)
+			if got.Cmp(want) != 0 {
+				t.Errorf("ParseBig(%q,%q) = %s, want %s", c.in, c.iso, got, want)
+			}
+		})
+	}
+}
+
+func TestPow10Int64(t *testing.T) {
+	t.Parallel()
+
+	got, err := pow10int64(18)
+	if err != nil || got != 1000000000000000000 {
+		t.Fatalf("pow10int64(18) = %d, %v", got, err)
+	}
+
+	if _, err := pow10int64(19); !errors.Is(err, ErrOverflow) {
+		t.Fatalf("pow10int64(19) error = %v, want ErrOverflow", err)
+	}
+}
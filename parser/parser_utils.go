@@ -2,6 +2,7 @@ package parser
 
 import (
 	"fmt"
+	"math"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -90,42 +91,32 @@ func containsSign(s string) bool {
 	return contains(allowed, r)
 }
 
-func atoiRunes(rs []rune) (int64, error) {
-	var n int64
+func atoiRunes(rs []rune) (uint64, error) {
+	var n uint64
 	for _, r := range rs {
 		if r < '0' || r > '9' {
 			return 0, fmt.Errorf("not a digit: %q", r)
 		}
-		n = n*10 + int64(r-'0')
+		d := uint64(r - '0')
+		if n > (math.MaxUint64-d)/10 {
+			return 0, ErrOverflow
+		}
+		n = n*10 + d
 	}
 	return n, nil
 }
 
-func pow10int64(n int) int64 {
-	switch n {
-	case 0:
-		return 1
-	case 1:
-		return 10
-	case 2:
-		return 100
-	case 3:
-		return 1000
-	case 4:
-		return 10000
-	case 5:
-		return 100000
-	case 6:
-		return 1000000
-	case 7:
-		return 10000000
-	case 8:
-		return 100000000
-	case 9:
-		return 1000000000
-	default:
-		panic("fraction digits out of range")
+// pow10int64 returns 10^n for the fraction digits that fit into an int64.
+func pow10int64(n int) (int64, error) {
+	if n < 0 || n > 18 {
+		return 0, fmt.Errorf("%w: 10^%d", ErrOverflow, n)
+	}
+
+	p := int64(1)
+	for i := 0; i < n; i++ {
+		p *= 10
 	}
+	return p, nil
 }
 
 func contains(slice []rune, r rune) bool {
@@ -0,0 +1,80 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+)
+
+// ErrDivisionByZero happens when Money is divided by zero Money, such as the
+// ratio or percentage of zero Money.
+var ErrDivisionByZero = errors.New("division by zero")
+
+var hundred = big.NewRat(100, 1)
+
+// Ratio returns the exact ratio of Self to om, e.g. 1/4 for 25.00 and 100.00.
+func (m *Money) Ratio(om *Money) (*big.Rat, error) {
+	if err := m.assertSameCurrency(om); err != nil {
+		return nil, err
+	}
+	if om.amount == 0 {
+		return nil, ErrDivisionByZero
+	}
+
+	return big.NewRat(m.amount, om.amount), nil
+}
+
+// PercentOf returns the exact percentage Self is of om, e.g. 12.5 for 12.50
+// and 100.00.
+func (m *Money) PercentOf(om *Money) (*big.Rat, error) {
+	r, err := m.Ratio(om)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Mul(r, hundred), nil
+}
+
+// PercentChange returns the exact percentage change from om to Self, e.g. 25
+// from 80.00 to 100.00 and -20 from 100.00 to 80.00.
+func (m *Money) PercentChange(om *Money) (*big.Rat, error) {
+	r, err := m.Ratio(om)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Sub(r, big.NewRat(1, 1))
+	return r.Mul(r, hundred), nil
+}
+
+// AddPercent returns new Money struct with value representing Self increased
+// by the exact percentage p, e.g. 12.5 for 12.5%, rounded using mode.
+func (m *Money) AddPercent(p *big.Rat, mode RoundingMode) (*Money, error) {
+	f := new(big.Rat).Quo(p, hundred)
+	return m.MultiplyRat(f.Add(f, big.NewRat(1, 1)), mode)
+}
+
+// SubtractPercent returns new Money struct with value representing Self
+// decreased by the exact percentage p, e.g. 12.5 for 12.5%, rounded using mode.
+func (m *Money) SubtractPercent(p *big.Rat, mode RoundingMode) (*Money, error) {
+	f := new(big.Rat).Quo(p, hundred)
+	return m.MultiplyRat(f.Sub(big.NewRat(1, 1), f), mode)
+}
+
+// Margin returns the exact gross margin of Self as a price over cost, the
+// profit as a percentage of the price, e.g. 20 for a price of 125.00 and a
+// cost of 100.00.
+func (m *Money) Margin(cost *Money) (*big.Rat, error) {
+	r, err := cost.Ratio(m)
+	if err != nil {
+		return nil, err
+	}
+
+	r.Sub(big.NewRat(1, 1), r)
+	return r.Mul(r, hundred), nil
+}
+
+// Markup returns the exact markup of Self as a price over cost, the profit as
+// a percentage of the cost, e.g. 25 for a price of 125.00 and a cost of 100.00.
+func (m *Money) Markup(cost *Money) (*big.Rat, error) {
+	return m.PercentChange(cost)
+}
@@ -0,0 +1,130 @@
+package money
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestMoney_Ratio(t *testing.T) {
+	tests := []struct {
+		a, b    int64
+		ratio   *big.Rat
+		percent *big.Rat
+		change  *big.Rat
+	}{
+		{2500, 10000, big.NewRat(1, 4), big.NewRat(25, 1), big.NewRat(-75, 1)},
+		{1250, 10000, big.NewRat(1, 8), big.NewRat(25, 2), big.NewRat(-175, 2)},
+		{10000, 8000, big.NewRat(5, 4), big.NewRat(125, 1), big.NewRat(25, 1)},
+		{100, 300, big.NewRat(1, 3), big.NewRat(100, 3), big.NewRat(-200, 3)},
+		{-500, 1000, big.NewRat(-1, 2), big.NewRat(-50, 1), big.NewRat(-150, 1)},
+		{0, 1000, new(big.Rat), new(big.Rat), big.NewRat(-100, 1)},
+	}
+
+	for _, tc := range tests {
+		a, b := New(tc.a, EUR), New(tc.b, EUR)
+
+		ratio, err := a.Ratio(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		percent, err := a.PercentOf(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		change, err := a.PercentChange(b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if ratio.Cmp(tc.ratio) != 0 || percent.Cmp(tc.percent) != 0 || change.Cmp(tc.change) != 0 {
+			t.Errorf("Expected %d and %d to be %s, %s%% and %s%% got %s, %s%% and %s%%", tc.a, tc.b,
+				tc.ratio, tc.percent, tc.change, ratio, percent, change)
+		}
+	}
+
+	if _, err := New(100, EUR).Ratio(New(100, USD)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected %v got %v", ErrCurrencyMismatch, err)
+	}
+	if _, err := New(100, EUR).PercentOf(New(0, EUR)); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Expected %v got %v", ErrDivisionByZero, err)
+	}
+	if _, err := New(100, EUR).PercentChange(New(0, EUR)); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Expected %v got %v", ErrDivisionByZero, err)
+	}
+}
+
+func TestMoney_AddPercent(t *testing.T) {
+	tests := []struct {
+		amount   int64
+		percent  *big.Rat
+		mode     RoundingMode
+		add      int64
+		subtract int64
+	}{
+		{10000, big.NewRat(25, 2), RoundHalfUp, 11250, 8750},
+		{999, big.NewRat(25, 2), RoundHalfUp, 1124, 874},
+		{999, big.NewRat(25, 2), RoundDown, 1123, 874},
+		{999, big.NewRat(25, 2), RoundUp, 1124, 875},
+		{-999, big.NewRat(25, 2), RoundHalfUp, -1124, -874},
+		{1000, big.NewRat(1, 3), RoundHalfEven, 1003, 997},
+		{1000, new(big.Rat), RoundHalfUp, 1000, 1000},
+		{1000, big.NewRat(100, 1), RoundHalfUp, 2000, 0},
+	}
+
+	for _, tc := range tests {
+		add, err := New(tc.amount, GBP).AddPercent(tc.percent, tc.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		subtract, err := New(tc.amount, GBP).SubtractPercent(tc.percent, tc.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if add.Amount() != tc.add || subtract.Amount() != tc.subtract || add.Currency().Code != GBP {
+			t.Errorf("Expected %d +/- %s%% to be %d and %d got %d and %d", tc.amount, tc.percent, tc.add, tc.subtract,
+				add.Amount(), subtract.Amount())
+		}
+	}
+}
+
+func TestMoney_Margin(t *testing.T) {
+	tests := []struct {
+		price, cost    int64
+		margin, markup *big.Rat
+	}{
+		{12500, 10000, big.NewRat(20, 1), big.NewRat(25, 1)},
+		{10000, 6000, big.NewRat(40, 1), big.NewRat(200, 3)},
+		{9000, 10000, big.NewRat(-100, 9), big.NewRat(-10, 1)},
+		{5000, 5000, new(big.Rat), new(big.Rat)},
+	}
+
+	for _, tc := range tests {
+		price, cost := New(tc.price, USD), New(tc.cost, USD)
+
+		margin, err := price.Margin(cost)
+		if err != nil {
+			t.Fatal(err)
+		}
+		markup, err := price.Markup(cost)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if margin.Cmp(tc.margin) != 0 || markup.Cmp(tc.markup) != 0 {
+			t.Errorf("Expected price %d and cost %d to be %s%% margin and %s%% markup got %s%% and %s%%", tc.price, tc.cost,
+				tc.margin, tc.markup, margin, markup)
+		}
+	}
+
+	if _, err := New(100, USD).Margin(New(50, EUR)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Expected %v got %v", ErrCurrencyMismatch, err)
+	}
+	if _, err := New(0, USD).Margin(New(50, USD)); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Expected %v got %v", ErrDivisionByZero, err)
+	}
+	if _, err := New(100, USD).Markup(New(0, USD)); !errors.Is(err, ErrDivisionByZero) {
+		t.Errorf("Expected %v got %v", ErrDivisionByZero, err)
+	}
+}
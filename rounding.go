@@ -0,0 +1,140 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// RoundingMode tells how an exact amount that falls between two minor units is rounded.
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds to the nearest minor unit, and halves away from zero.
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfDown rounds to the nearest minor unit, and halves towards zero.
+	RoundHalfDown
+	// RoundHalfEven rounds to the nearest minor unit, and halves to the even one.
+	RoundHalfEven
+	// RoundUp rounds away from zero.
+	RoundUp
+	// RoundDown rounds towards zero, like Split and Allocate do.
+	RoundDown
+	// RoundCeiling rounds towards positive infinity.
+	RoundCeiling
+	// RoundFloor rounds towards negative infinity.
+	RoundFloor
+)
+
+var roundingModeNames = [...]string{
+	RoundHalfUp:   "RoundHalfUp",
+	RoundHalfDown: "RoundHalfDown",
+	RoundHalfEven: "RoundHalfEven",
+	RoundUp:       "RoundUp",
+	RoundDown:     "RoundDown",
+	RoundCeiling:  "RoundCeiling",
+	RoundFloor:    "RoundFloor",
+}
+
+func (mode RoundingMode) String() string {
+	if mode < 0 || int(mode) >= len(roundingModeNames) {
+		return fmt.Sprintf("RoundingMode(%d)", int(mode))
+	}
+	return roundingModeNames[mode]
+}
+
+// NewFromRat creates and returns new instance of Money from an exact amount in
+// major units, rounded to the minor units of the currency using mode.
+// It returns ErrAmountOverflow if the rounded amount does not fit into an Amount.
+func NewFromRat(r *big.Rat, code string, mode RoundingMode) (*Money, error) {
+	c := newCurrency(code).get()
+	minor := new(big.Rat).Mul(r, new(big.Rat).SetInt64(pow10(c.Fraction)))
+
+	amount, err := roundRat(minor, mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Money{amount: amount, currency: c}, nil
+}
+
+// Rat returns the exact amount of Money in major units.
+func (m *Money) Rat() *big.Rat {
+	return big.NewRat(m.amount, pow10(m.currency.get().Fraction))
+}
+
+// MultiplyRat returns new Money struct with value representing Self multiplied
+// by the exact factor r, rounded to minor units using mode.
+// It returns ErrAmountOverflow if the result does not fit into an Amount.
+func (m *Money) MultiplyRat(r *big.Rat, mode RoundingMode) (*Money, error) {
+	amount, err := roundRat(new(big.Rat).Mul(new(big.Rat).SetInt64(m.amount), r), mode)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Money{amount: amount, currency: m.currency}, nil
+}
+
+// RoundCash returns new Money struct with value rounded to the CashIncrement of
+// its currency using mode, as cash payments are, e.g. CHF 10.23 is rounded to
+// CHF 10.25 with RoundHalfUp. Money of currencies without a CashIncrement is
+// returned unchanged.
+func (m *Money) RoundCash(mode RoundingMode) (*Money, error) {
+	inc := int64(m.currency.get().CashIncrement)
+	if inc < 1 {
+		inc = 1
+	}
+
+	q, err := roundRat(big.NewRat(m.amount, inc), mode)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := q * inc
+	if amount/inc != q {
+		return nil, fmt.Errorf("%w: %d rounded to a multiple of %d", ErrAmountOverflow, m.amount, inc)
+	}
+
+	return &Money{amount: amount, currency: m.currency}, nil
+}
+
+// roundRat rounds r to an integer using mode.
+func roundRat(r *big.Rat, mode RoundingMode) (Amount, error) {
+	q, rem := new(big.Int).QuoRem(r.Num(), r.Denom(), new(big.Int))
+
+	if rem.Sign() != 0 {
+		// compare the remainder with half of the denominator
+		half := new(big.Int).Abs(rem)
+		half.Lsh(half, 1)
+		cmp := half.Cmp(r.Denom())
+
+		var away bool
+		switch mode {
+		case RoundHalfUp:
+			away = cmp >= 0
+		case RoundHalfDown:
+			away = cmp > 0
+		case RoundHalfEven:
+			away = cmp > 0 || cmp == 0 && q.Bit(0) == 1
+		case RoundUp:
+			away = true
+		case RoundDown:
+			away = false
+		case RoundCeiling:
+			away = r.Sign() > 0
+		case RoundFloor:
+			away = r.Sign() < 0
+		default:
+			return 0, fmt.Errorf("unknown rounding mode %s", mode)
+		}
+
+		if away {
+			q.Add(q, big.NewInt(int64(r.Sign())))
+		}
+	}
+
+	if !q.IsInt64() {
+		return 0, fmt.Errorf("%w: %s", ErrAmountOverflow, r.FloatString(0))
+	}
+
+	return q.Int64(), nil
+}
@@ -0,0 +1,145 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestNewFromRat(t *testing.T) {
+	tests := []struct {
+		amount string
+		mode   RoundingMode
+		want   int64
+	}{
+		{"1.005", RoundHalfUp, 101},
+		{"-1.005", RoundHalfUp, -101},
+		{"1.005", RoundHalfDown, 100},
+		{"-1.005", RoundHalfDown, -100},
+		{"1.005", RoundHalfEven, 100},
+		{"1.015", RoundHalfEven, 102},
+		{"-1.015", RoundHalfEven, -102},
+		{"1.006", RoundHalfDown, 101},
+		{"1.001", RoundUp, 101},
+		{"-1.001", RoundUp, -101},
+		{"1.009", RoundDown, 100},
+		{"-1.009", RoundDown, -100},
+		{"1.001", RoundCeiling, 101},
+		{"-1.009", RoundCeiling, -100},
+		{"1.009", RoundFloor, 100},
+		{"-1.001", RoundFloor, -101},
+		{"1/3", RoundHalfUp, 33},
+		{"2/3", RoundHalfUp, 67},
+		{"12.34", RoundUp, 1234},
+	}
+
+	for _, tc := range tests {
+		r, _ := new(big.Rat).SetString(tc.amount)
+		m, err := NewFromRat(r, USD, tc.mode)
+		if err != nil {
+			t.Fatalf("NewFromRat(%s, %s) error = %v", tc.amount, tc.mode, err)
+		}
+		if m.Amount() != tc.want || m.Currency().Code != USD {
+			t.Errorf("Expected %s %s to be %d got %d", tc.amount, tc.mode, tc.want, m.Amount())
+		}
+	}
+
+	if _, err := NewFromRat(big.NewRat(1e18, 1), USD, RoundHalfUp); !errors.Is(err, ErrAmountOverflow) {
+		t.Errorf("Expected %v got %v", ErrAmountOverflow, err)
+	}
+	if _, err := NewFromRat(big.NewRat(1, 3), USD, RoundingMode(42)); err == nil {
+		t.Errorf("Expected error for unknown rounding mode")
+	}
+}
+
+func TestMoney_Rat(t *testing.T) {
+	if r := New(1234, USD).Rat(); r.Cmp(big.NewRat(1234, 100)) != 0 {
+		t.Errorf("Expected 617/50 got %s", r)
+	}
+	if r := New(-5, JPY).Rat(); r.Cmp(big.NewRat(-5, 1)) != 0 {
+		t.Errorf("Expected -5 got %s", r)
+	}
+}
+
+func TestMoney_MultiplyRat(t *testing.T) {
+	tests := []struct {
+		amount int64
+		factor *big.Rat
+		mode   RoundingMode
+		want   int64
+	}{
+		{1000, big.NewRat(1, 3), RoundHalfUp, 333},
+		{1000, big.NewRat(2, 3), RoundDown, 666},
+		{-1000, big.NewRat(2, 3), RoundHalfUp, -667},
+		{1999, big.NewRat(1, 2), RoundHalfEven, 1000},
+		{1997, big.NewRat(1, 2), RoundHalfEven, 998},
+	}
+
+	for _, tc := range tests {
+		m, err := New(tc.amount, EUR).MultiplyRat(tc.factor, tc.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if m.Amount() != tc.want || m.Currency().Code != EUR {
+			t.Errorf("Expected %d * %s to be %d got %d", tc.amount, tc.factor, tc.want, m.Amount())
+		}
+	}
+}
+
+func TestMoney_RoundCash(t *testing.T) {
+	tests := []struct {
+		amount int64
+		code   string
+		mode   RoundingMode
+		want   int64
+	}{
+		{1023, CHF, RoundHalfUp, 1025},
+		{1022, CHF, RoundHalfUp, 1020},
+		{1022, CHF, RoundUp, 1025},
+		{1027, CHF, RoundDown, 1025},
+		{-1023, CHF, RoundHalfUp, -1025},
+		{-1022, CHF, RoundFloor, -1025},
+		{1025, CHF, RoundHalfEven, 1025},
+		{12250, SEK, RoundHalfEven, 12200},
+		{12350, SEK, RoundHalfEven, 12400},
+		{1999, CAD, RoundHalfUp, 2000},
+		{1995, NZD, RoundHalfUp, 2000},
+		{1994, NZD, RoundHalfUp, 1990},
+		{12345, SEK, RoundHalfUp, 12300},
+		{12350, SEK, RoundHalfDown, 12300},
+		{12351, SEK, RoundHalfDown, 12400},
+		{1999, USD, RoundHalfUp, 1999},
+		{1999, EUR, RoundUp, 1999},
+	}
+
+	for _, tc := range tests {
+		m, err := New(tc.amount, tc.code).RoundCash(tc.mode)
+		if err != nil {
+			t.Fatalf("RoundCash(%s) error = %v", tc.mode, err)
+		}
+		if m.Amount() != tc.want || m.Currency().Code != tc.code {
+			t.Errorf("Expected %d %s rounded %s to be %d got %d", tc.amount, tc.code, tc.mode, tc.want, m.Amount())
+		}
+	}
+
+	if _, err := New(1023, CHF).RoundCash(RoundingMode(-1)); err == nil {
+		t.Errorf("Expected error for unknown rounding mode")
+	}
+	if _, err := New(math.MaxInt64, CHF).RoundCash(RoundUp); !errors.Is(err, ErrAmountOverflow) {
+		t.Errorf("Expected %v got %v", ErrAmountOverflow, err)
+	}
+}
+
+func TestCurrency_CashIncrement(t *testing.T) {
+	c := AddCurrency("XCI", "C", "$1", ".", ",", 2)
+	c.CashIncrement = 25
+
+	m, err := New(1013, "XCI").RoundCash(RoundHalfUp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Amount() != 1025 {
+		t.Errorf("Expected 1025 got %d", m.Amount())
+	}
+}
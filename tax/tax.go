@@ -0,0 +1,419 @@
+// Package tax calculates VAT, GST and sales tax on [money.Money] line items.
+//
+// Rates are exact decimal percentages. A rate is either charged on the net
+// amount, or compounded on the net amount plus the taxes of the rates before
+// it, like the Quebec QST used to be:
+//
+//	gst, _ := tax.NewRate("GST", "5")
+//	qst, _ := tax.NewRate("QST", "9.5")
+//	qst.Compound = true
+//
+//	b, err := tax.Calculate([]tax.Line{
+//		{Amount: money.New(1999, money.CAD), Rates: []tax.Rate{gst, qst}},
+//		{Amount: money.New(500, money.CAD), Rates: []tax.Rate{gst}},
+//	})
+//
+// Line amounts are net prices by default, or gross prices including tax with
+// [WithPricing]([Inclusive]). Taxes are rounded for every line, or once for the
+// whole invoice with [WithRounding]([PerInvoice]), using a [money.RoundingMode].
+//
+// The [Breakdown] always adds up exactly: the net amount and the taxes of every
+// line sum to its gross amount, and the lines sum to the totals. The rounded
+// tax of a line is apportioned to its rates, and the rounded tax of an invoice
+// to the rates of all its lines, with [money.Money.AllocateRat] in proportion
+// to their exact taxes. Refunds are apportioned apart from charges, so every
+// part keeps the sign of its exact tax.
+package tax
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/Rhymond/go-money"
+)
+
+var (
+	// ErrInvalidRate is returned when a rate is not a non-negative decimal
+	// percentage, or appears twice on the same line.
+	ErrInvalidRate = errors.New("invalid rate")
+	// ErrInvalidLine is returned when a line has no amount.
+	ErrInvalidLine = errors.New("invalid line")
+	// ErrNoLines is returned when calculating the tax of no lines, which have no currency.
+	ErrNoLines = errors.New("no lines")
+)
+
+// Rate is a tax rate.
+type Rate struct {
+	Name string
+	// Percent is the rate as an exact percentage, e.g. 20 for 20%.
+	Percent *big.Rat
+	// Compound rates are charged on the net amount plus the taxes of the rates
+	// before them on a line; other rates are charged on the net amount only.
+	Compound bool
+}
+
+// NewRate returns a Rate charged on the net amount. percent is an exact
+// decimal percentage, such as "20" or "8.875".
+func NewRate(name, percent string) (Rate, error) {
+	p, ok := new(big.Rat).SetString(percent)
+	if !ok || p.Sign() < 0 {
+		return Rate{}, fmt.Errorf("%w: %s %q", ErrInvalidRate, name, percent)
+	}
+
+	return Rate{Name: name, Percent: p}, nil
+}
+
+// key identifies equal rates across lines.
+func (r Rate) key() string {
+	return fmt.Sprintf("%s\x00%s\x00%t", r.Name, r.Percent.RatString(), r.Compound)
+}
+
+// Pricing tells whether line amounts include tax.
+type Pricing int
+
+const (
+	// Exclusive line amounts are net prices; tax is added to them.
+	Exclusive Pricing = iota
+	// Inclusive line amounts are gross prices; tax is included in them.
+	Inclusive
+)
+
+// Rounding tells when taxes are rounded to the minor unit of their currency.
+type Rounding int
+
+const (
+	// PerLine rounds the tax of every line.
+	PerLine Rounding = iota
+	// PerInvoice rounds the tax of all lines together, and apportions it to the lines.
+	PerInvoice
+)
+
+// Line is a line item with the rates charged on it.
+type Line struct {
+	// Amount is the net or gross price of the line, depending on the Pricing.
+	Amount *money.Money
+	Rates  []Rate
+}
+
+// TaxAmount is the tax charged for a rate.
+type TaxAmount struct {
+	Rate   Rate
+	Amount *money.Money
+}
+
+// LineBreakdown is the tax of a line. Net plus the amounts of Taxes equals Gross.
+type LineBreakdown struct {
+	Net *money.Money
+	// Taxes are in the order of the rates of the line.
+	Taxes []TaxAmount
+	Gross *money.Money
+}
+
+// Breakdown is the tax of a list of lines. Net plus the amounts of Taxes
+// equals Gross, and each of them is the sum of the same part of Lines.
+type Breakdown struct {
+	Lines []LineBreakdown
+	Net   *money.Money
+	// Taxes has the total for every distinct rate, in the order they first appear on the lines.
+	Taxes []TaxAmount
+	Gross *money.Money
+}
+
+// Option applies a modification to [Options] and returns it.
+type Option func(o *Options) *Options
+
+// WithPricing sets whether line amounts include tax.
+func WithPricing(p Pricing) Option {
+	return func(o *Options) *Options {
+		o.Pricing = p
+		return o
+	}
+}
+
+// WithRounding sets when taxes are rounded.
+func WithRounding(r Rounding) Option {
+	return func(o *Options) *Options {
+		o.Rounding = r
+		return o
+	}
+}
+
+// WithRoundingMode sets how taxes are rounded to the minor unit of their currency.
+func WithRoundingMode(mode money.RoundingMode) Option {
+	return func(o *Options) *Options {
+		o.RoundingMode = mode
+		return o
+	}
+}
+
+// Options configures [Calculate].
+type Options struct {
+	Pricing      Pricing
+	Rounding     Rounding
+	RoundingMode money.RoundingMode
+}
+
+// DefaultOptions returns [Options] with
+//
+// Pricing=Exclusive, Rounding=PerLine and RoundingMode=money.RoundHalfUp.
+func DefaultOptions() *Options {
+	return &Options{
+		Pricing:      Exclusive,
+		Rounding:     PerLine,
+		RoundingMode: money.RoundHalfUp,
+	}
+}
+
+func newOptions(opts []Option) *Options {
+	opt := DefaultOptions()
+	for _, o := range opts {
+		opt = o(opt)
+	}
+
+	return opt
+}
+
+// Calculate returns the tax breakdown of lines, which must all have the same currency.
+func Calculate(lines []Line, opts ...Option) (*Breakdown, error) {
+	o := newOptions(opts)
+
+	if len(lines) == 0 {
+		return nil, ErrNoLines
+	}
+
+	// exact[l][i] is the unrounded tax of rate i of line l, in major units
+	exact := make([][]*big.Rat, len(lines))
+	for l, line := range lines {
+		if line.Amount == nil || line.Amount.Currency() == nil {
+			return nil, fmt.Errorf("%w: line %d has no amount", ErrInvalidLine, l)
+		}
+		if !line.Amount.SameCurrency(lines[0].Amount) {
+			return nil, fmt.Errorf("line %d: %w", l, money.ErrCurrencyMismatch)
+		}
+
+		e, err := exactTaxes(line, o.Pricing)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", l, err)
+		}
+		exact[l] = e
+	}
+
+	code := lines[0].Amount.Currency().Code
+	taxes := make([][]*money.Money, len(lines))
+	if o.Rounding == PerInvoice {
+		if err := roundPerInvoice(lines, exact, taxes, code, o.RoundingMode); err != nil {
+			return nil, err
+		}
+	} else {
+		for l := range lines {
+			t, err := roundTotal(exact[l], code, o.RoundingMode)
+			if err != nil {
+				return nil, err
+			}
+			if taxes[l], err = apportion(t, exact[l], o.RoundingMode); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return breakdown(lines, taxes, code, o.Pricing)
+}
+
+// exactTaxes returns the unrounded taxes of the rates of a line.
+func exactTaxes(line Line, pricing Pricing) ([]*big.Rat, error) {
+	seen := make(map[string]bool, len(line.Rates))
+
+	// factors[i] is the tax of rate i per unit of net amount
+	factors := make([]*big.Rat, len(line.Rates))
+	sum := new(big.Rat)
+	for i, r := range line.Rates {
+		if r.Percent == nil || r.Percent.Sign() < 0 {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRate, r.Name)
+		}
+		if seen[r.key()] {
+			return nil, fmt.Errorf("%w: %s appears twice", ErrInvalidRate, r.Name)
+		}
+		seen[r.key()] = true
+
+		f := new(big.Rat).Quo(r.Percent, big.NewRat(100, 1))
+		if r.Compound {
+			f.Mul(f, new(big.Rat).Add(big.NewRat(1, 1), sum))
+		}
+		factors[i] = f
+		sum = new(big.Rat).Add(sum, f)
+	}
+
+	net := line.Amount.Rat()
+	if pricing == Inclusive {
+		net.Quo(net, sum.Add(sum, big.NewRat(1, 1)))
+	}
+
+	res := make([]*big.Rat, len(factors))
+	for i, f := range factors {
+		res[i] = new(big.Rat).Mul(net, f)
+	}
+
+	return res, nil
+}
+
+// roundPerInvoice rounds the total tax of all lines and apportions it to the
+// taxes of every rate of every line at once, so each is within a minor unit
+// of its exact tax.
+func roundPerInvoice(lines []Line, exact [][]*big.Rat, taxes [][]*money.Money, code string, mode money.RoundingMode) error {
+	var all []*big.Rat
+	for l := range lines {
+		all = append(all, exact[l]...)
+	}
+
+	total, err := roundTotal(all, code, mode)
+	if err != nil {
+		return err
+	}
+	parts, err := apportion(total, all, mode)
+	if err != nil {
+		return err
+	}
+
+	for l := range lines {
+		taxes[l], parts = parts[:len(exact[l])], parts[len(exact[l]):]
+	}
+
+	return nil
+}
+
+// roundTotal returns the sum of exact taxes rounded using mode.
+func roundTotal(exact []*big.Rat, code string, mode money.RoundingMode) (*money.Money, error) {
+	return money.NewFromRat(sumRats(exact), code, mode)
+}
+
+// apportion allocates the rounded total t to parts in proportion to their
+// exact amounts. The negative parts get the sum of their exact amounts rounded
+// using mode and the positive parts the rest of t, each allocated on absolute
+// values with the sign reapplied, so refunds and charges keep their signs.
+// Parts with no tax get none of t.
+func apportion(t *money.Money, exact []*big.Rat, mode money.RoundingMode) ([]*money.Money, error) {
+	code := t.Currency().Code
+	res := make([]*money.Money, len(exact))
+	for i := range res {
+		res[i] = money.New(0, code)
+	}
+
+	var pos, neg []int
+	for i, e := range exact {
+		switch e.Sign() {
+		case 1:
+			pos = append(pos, i)
+		case -1:
+			neg = append(neg, i)
+		}
+	}
+
+	tPos, tNeg := t, money.New(0, code)
+	if len(pos) == 0 {
+		tPos, tNeg = tNeg, t
+	} else if len(neg) > 0 {
+		negExact := make([]*big.Rat, len(neg))
+		for n, i := range neg {
+			negExact[n] = exact[i]
+		}
+
+		var err error
+		if tNeg, err = roundTotal(negExact, code, mode); err != nil {
+			return nil, err
+		}
+		if tPos, err = t.Subtract(tNeg); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, g := range []struct {
+		idx []int
+		t   *money.Money
+	}{{pos, tPos}, {neg, tNeg}} {
+		if len(g.idx) == 0 {
+			continue
+		}
+
+		ratios := make([]*big.Rat, len(g.idx))
+		for n, i := range g.idx {
+			ratios[n] = new(big.Rat).Abs(exact[i])
+		}
+		parts, err := g.t.Absolute().AllocateRat(ratios)
+		if err != nil {
+			return nil, err
+		}
+		for n, i := range g.idx {
+			if g.t.IsNegative() {
+				parts[n] = parts[n].Negative()
+			}
+			res[i] = parts[n]
+		}
+	}
+
+	return res, nil
+}
+
+// breakdown sums the taxes of every line into a Breakdown.
+func breakdown(lines []Line, taxes [][]*money.Money, code string, pricing Pricing) (*Breakdown, error) {
+	zero := money.New(0, code)
+	b := &Breakdown{Lines: make([]LineBreakdown, len(lines)), Net: zero, Gross: zero}
+	index := make(map[string]int)
+
+	for l, line := range lines {
+		lb := LineBreakdown{Taxes: make([]TaxAmount, len(line.Rates))}
+
+		tax := zero
+		for i, r := range line.Rates {
+			t := taxes[l][i]
+			lb.Taxes[i] = TaxAmount{Rate: r, Amount: t}
+
+			var err error
+			if tax, err = tax.Add(t); err != nil {
+				return nil, err
+			}
+
+			k, ok := index[r.key()]
+			if !ok {
+				k = len(b.Taxes)
+				index[r.key()] = k
+				b.Taxes = append(b.Taxes, TaxAmount{Rate: r, Amount: zero})
+			}
+			if b.Taxes[k].Amount, err = b.Taxes[k].Amount.Add(t); err != nil {
+				return nil, err
+			}
+		}
+
+		var err error
+		if pricing == Inclusive {
+			lb.Gross = line.Amount
+			lb.Net, err = line.Amount.Subtract(tax)
+		} else {
+			lb.Net = line.Amount
+			lb.Gross, err = line.Amount.Add(tax)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if b.Net, err = b.Net.Add(lb.Net); err != nil {
+			return nil, err
+		}
+		if b.Gross, err = b.Gross.Add(lb.Gross); err != nil {
+			return nil, err
+		}
+
+		b.Lines[l] = lb
+	}
+
+	return b, nil
+}
+
+func sumRats(rs []*big.Rat) *big.Rat {
+	sum := new(big.Rat)
+	for _, r := range rs {
+		sum.Add(sum, r)
+	}
+
+	return sum
+}
@@ -0,0 +1,335 @@
+package tax
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/Rhymond/go-money"
+)
+
+func mustRate(t *testing.T, name, percent string) Rate {
+	t.Helper()
+
+	r, err := NewRate(name, percent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+// checkBreakdown asserts that the parts of b add up exactly, and that every
+// tax is within a minor unit of its exact tax with pricing.
+func checkBreakdown(t *testing.T, b *Breakdown, pricing Pricing) {
+	t.Helper()
+
+	var net, gross int64
+	taxes := make(map[string]int64)
+	for i, lb := range b.Lines {
+		line := Line{Amount: lb.Net}
+		if pricing == Inclusive {
+			line.Amount = lb.Gross
+		}
+		for _, ta := range lb.Taxes {
+			line.Rates = append(line.Rates, ta.Rate)
+		}
+		exact, err := exactTaxes(line, pricing)
+		if err != nil {
+			t.Fatal(err)
+		}
+		unit := money.New(1, line.Amount.Currency().Code).Rat()
+
+		tax := int64(0)
+		for j, ta := range lb.Taxes {
+			tax += ta.Amount.Amount()
+			taxes[ta.Rate.key()] += ta.Amount.Amount()
+
+			diff := new(big.Rat).Sub(ta.Amount.Rat(), exact[j])
+			if diff.Abs(diff).Cmp(unit) > 0 {
+				t.Errorf("Expected line %d %s %d within a minor unit of %s", i, ta.Rate.Name, ta.Amount.Amount(),
+					new(big.Rat).Quo(exact[j], unit).FloatString(3))
+			}
+		}
+		if lb.Net.Amount()+tax != lb.Gross.Amount() {
+			t.Errorf("Expected line %d net %d + tax %d to be gross %d", i, lb.Net.Amount(), tax, lb.Gross.Amount())
+		}
+		net += lb.Net.Amount()
+		gross += lb.Gross.Amount()
+	}
+
+	var tax int64
+	for _, ta := range b.Taxes {
+		if ta.Amount.Amount() != taxes[ta.Rate.key()] {
+			t.Errorf("Expected %s total %d got %d", ta.Rate.Name, taxes[ta.Rate.key()], ta.Amount.Amount())
+		}
+		tax += ta.Amount.Amount()
+	}
+	if net != b.Net.Amount() || gross != b.Gross.Amount() || b.Net.Amount()+tax != b.Gross.Amount() {
+		t.Errorf("Expected totals net %d gross %d got net %d tax %d gross %d", net, gross, b.Net.Amount(), tax, b.Gross.Amount())
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	vat := mustRate(t, "VAT", "20")
+	gst := mustRate(t, "GST", "5")
+	pst := mustRate(t, "PST", "7")
+	qst := mustRate(t, "QST", "9.5")
+	qst.Compound = true
+
+	tests := []struct {
+		name  string
+		lines []Line
+		opts  []Option
+		net   int64
+		taxes []int64
+		gross int64
+	}{
+		{
+			name:  "exclusive",
+			lines: []Line{{Amount: money.New(1000, money.GBP), Rates: []Rate{vat}}},
+			net:   1000,
+			taxes: []int64{200},
+			gross: 1200,
+		},
+		{
+			name:  "inclusive",
+			lines: []Line{{Amount: money.New(999, money.GBP), Rates: []Rate{vat}}},
+			opts:  []Option{WithPricing(Inclusive)},
+			net:   832,
+			taxes: []int64{167},
+			gross: 999,
+		},
+		{
+			name:  "inclusive round down",
+			lines: []Line{{Amount: money.New(999, money.GBP), Rates: []Rate{vat}}},
+			opts:  []Option{WithPricing(Inclusive), WithRoundingMode(money.RoundDown)},
+			net:   833,
+			taxes: []int64{166},
+			gross: 999,
+		},
+		{
+			name:  "stacked",
+			lines: []Line{{Amount: money.New(1999, money.CAD), Rates: []Rate{gst, pst}}},
+			net:   1999,
+			taxes: []int64{100, 140},
+			gross: 2239,
+		},
+		{
+			name:  "compound",
+			lines: []Line{{Amount: money.New(10000, money.CAD), Rates: []Rate{gst, qst}}},
+			net:   10000,
+			taxes: []int64{500, 998},
+			gross: 11498,
+		},
+		{
+			name: "per line",
+			lines: []Line{
+				{Amount: money.New(10, money.CAD), Rates: []Rate{gst}},
+				{Amount: money.New(10, money.CAD), Rates: []Rate{gst}},
+				{Amount: money.New(10, money.CAD), Rates: []Rate{gst}},
+			},
+			net:   30,
+			taxes: []int64{3},
+			gross: 33,
+		},
+		{
+			name: "per invoice",
+			lines: []Line{
+				{Amount: money.New(10, money.CAD), Rates: []Rate{gst}},
+				{Amount: money.New(10, money.CAD), Rates: []Rate{gst}},
+				{Amount: money.New(10, money.CAD), Rates: []Rate{gst}},
+			},
+			opts:  []Option{WithRounding(PerInvoice)},
+			net:   30,
+			taxes: []int64{2},
+			gross: 32,
+		},
+		{
+			name: "per invoice mixed rates",
+			lines: []Line{
+				{Amount: money.New(1999, money.CAD), Rates: []Rate{gst, pst}},
+				{Amount: money.New(500, money.CAD), Rates: []Rate{gst}},
+				{Amount: money.New(-250, money.CAD), Rates: []Rate{gst, pst}},
+			},
+			opts:  []Option{WithRounding(PerInvoice)},
+			net:   2249,
+			taxes: []int64{112, 123},
+			gross: 2484,
+		},
+		{
+			name:  "no rates",
+			lines: []Line{{Amount: money.New(1000, money.GBP)}},
+			opts:  []Option{WithPricing(Inclusive)},
+			net:   1000,
+			gross: 1000,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := Calculate(tc.lines, tc.opts...)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			checkBreakdown(t, b, newOptions(tc.opts).Pricing)
+
+			if b.Net.Amount() != tc.net || b.Gross.Amount() != tc.gross {
+				t.Errorf("Expected net %d gross %d got net %d gross %d", tc.net, tc.gross, b.Net.Amount(), b.Gross.Amount())
+			}
+			if len(b.Taxes) != len(tc.taxes) {
+				t.Fatalf("Expected %d taxes got %d", len(tc.taxes), len(b.Taxes))
+			}
+			for i, want := range tc.taxes {
+				if got := b.Taxes[i].Amount.Amount(); got != want {
+					t.Errorf("Expected %s %d got %d", b.Taxes[i].Rate.Name, want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculate_PerInvoiceLines(t *testing.T) {
+	gst := mustRate(t, "GST", "5")
+	lines := []Line{
+		{Amount: money.New(10, money.CAD), Rates: []Rate{gst}},
+		{Amount: money.New(10, money.CAD), Rates: []Rate{gst}},
+		{Amount: money.New(10, money.CAD), Rates: []Rate{gst}},
+	}
+
+	b, err := Calculate(lines, WithRounding(PerInvoice))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int64{1, 1, 0}
+	for i, lb := range b.Lines {
+		if got := lb.Taxes[0].Amount.Amount(); got != want[i] {
+			t.Errorf("Expected line %d tax %d got %d", i, want[i], got)
+		}
+	}
+}
+
+func TestCalculate_Refunds(t *testing.T) {
+	vat := mustRate(t, "VAT", "20")
+	gst := mustRate(t, "GST", "5")
+
+	tests := []struct {
+		name  string
+		lines []Line
+		want  [][]int64
+	}{
+		{
+			name: "refund line",
+			lines: []Line{
+				{Amount: money.New(10000, money.GBP), Rates: []Rate{vat}},
+				{Amount: money.New(-5000, money.GBP), Rates: []Rate{vat}},
+			},
+			want: [][]int64{{2000}, {-1000}},
+		},
+		{
+			name: "rates of opposite sign",
+			lines: []Line{
+				{Amount: money.New(10000, money.GBP), Rates: []Rate{vat}},
+				{Amount: money.New(-20000, money.GBP), Rates: []Rate{gst}},
+			},
+			want: [][]int64{{2000}, {-1000}},
+		},
+		{
+			name: "refund of a rounded tax",
+			lines: []Line{
+				{Amount: money.New(1003, money.GBP), Rates: []Rate{vat, gst}},
+				{Amount: money.New(-1003, money.GBP), Rates: []Rate{vat, gst}},
+			},
+			want: [][]int64{{201, 50}, {-201, -50}},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, rounding := range []Rounding{PerLine, PerInvoice} {
+				b, err := Calculate(tc.lines, WithRounding(rounding))
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkBreakdown(t, b, Exclusive)
+
+				for l, w := range tc.want {
+					for i, want := range w {
+						if got := b.Lines[l].Taxes[i].Amount.Amount(); got != want {
+							t.Errorf("Expected rounding %d line %d tax %d to be %d got %d", rounding, l, i, want, got)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCalculate_AddsUp(t *testing.T) {
+	gst := mustRate(t, "GST", "5")
+	qst := mustRate(t, "QST", "9.975")
+	qst.Compound = true
+	vat := mustRate(t, "VAT", "8.1")
+	zero := mustRate(t, "Zero", "0")
+
+	for _, pricing := range []Pricing{Exclusive, Inclusive} {
+		for _, rounding := range []Rounding{PerLine, PerInvoice} {
+			for amount := int64(-37); amount < 2000; amount += 41 {
+				lines := []Line{
+					{Amount: money.New(amount, money.CAD), Rates: []Rate{gst, qst}},
+					{Amount: money.New(amount*3+1, money.CAD), Rates: []Rate{vat, zero}},
+					{Amount: money.New(7, money.CAD), Rates: []Rate{gst, vat}},
+				}
+
+				b, err := Calculate(lines, WithPricing(pricing), WithRounding(rounding))
+				if err != nil {
+					t.Fatal(err)
+				}
+				checkBreakdown(t, b, pricing)
+
+				for _, lb := range b.Lines {
+					for _, ta := range lb.Taxes {
+						if ta.Rate.Name == "Zero" && !ta.Amount.IsZero() {
+							t.Errorf("Expected no tax for a zero rate got %d", ta.Amount.Amount())
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestCalculate_Errors(t *testing.T) {
+	vat := mustRate(t, "VAT", "20")
+
+	tests := []struct {
+		name  string
+		lines []Line
+		err   error
+	}{
+		{"no lines", nil, ErrNoLines},
+		{"no amount", []Line{{Rates: []Rate{vat}}}, ErrInvalidLine},
+		{"currency mismatch", []Line{
+			{Amount: money.New(100, money.GBP), Rates: []Rate{vat}},
+			{Amount: money.New(100, money.EUR), Rates: []Rate{vat}},
+		}, money.ErrCurrencyMismatch},
+		{"duplicate rate", []Line{{Amount: money.New(100, money.GBP), Rates: []Rate{vat, vat}}}, ErrInvalidRate},
+		{"nil percent", []Line{{Amount: money.New(100, money.GBP), Rates: []Rate{{Name: "VAT"}}}}, ErrInvalidRate},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := Calculate(tc.lines); !errors.Is(err, tc.err) {
+				t.Errorf("Expected %v got %v", tc.err, err)
+			}
+		})
+	}
+
+	for _, p := range []string{"", "abc", "-5"} {
+		if _, err := NewRate("VAT", p); !errors.Is(err, ErrInvalidRate) {
+			t.Errorf("Expected NewRate(%q) to return %v got %v", p, ErrInvalidRate, err)
+		}
+	}
+}
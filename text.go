@@ -0,0 +1,149 @@
+package money
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidTextUnmarshal happens when Money or Currency fails to unmarshal from text or XML because of invalid data.
+var ErrInvalidTextUnmarshal = errors.New("invalid text unmarshal")
+
+// xmlCurrencyAttr is the attribute holding the currency code of Money encoded
+// as an XML element, as in ISO 20022 messages: <Amt Ccy="EUR">12.34</Amt>.
+const xmlCurrencyAttr = "Ccy"
+
+// MarshalText is implementation of encoding.TextMarshaler.
+// It encodes Money as its amount in major units followed by a space and the currency code, e.g. "12.34 USD".
+func (m Money) MarshalText() ([]byte, error) {
+	if m == (Money{}) {
+		m = *New(0, "")
+	}
+
+	return []byte(formatMajorUnits(m.amount, m.currency.get().Fraction) + " " + m.currency.Code), nil
+}
+
+// UnmarshalText is implementation of encoding.TextUnmarshaler.
+// It decodes Money from the format produced by MarshalText, e.g. "12.34 USD".
+// A zero amount without a currency code, as in "0.00 ", decodes to the zero Money.
+func (m *Money) UnmarshalText(b []byte) error {
+	s := string(b)
+	i := strings.LastIndexByte(s, ' ')
+	if i <= 0 {
+		return fmt.Errorf("%w: %q is not \"<amount> <currency>\"", ErrInvalidTextUnmarshal, s)
+	}
+
+	om, err := decodeMajorUnits(s[:i], s[i+1:])
+	if err != nil {
+		return wrapError(ErrInvalidTextUnmarshal, err)
+	}
+
+	*m = om
+	return nil
+}
+
+// decodeMajorUnits returns Money of amount in major units of the currency with
+// the given code, or the zero Money for a zero amount without a code, which is
+// how MarshalText, MarshalXML and StringJSONCodec encode it.
+func decodeMajorUnits(amount, code string) (Money, error) {
+	currency := newCurrency(code).get()
+	a, err := parseMajorUnits(amount, currency.Fraction)
+	if err != nil {
+		return Money{}, err
+	}
+
+	if code == "" {
+		if a != 0 {
+			return Money{}, fmt.Errorf("amount %s has no currency", amount)
+		}
+		return Money{}, nil
+	}
+
+	return Money{amount: a, currency: currency}, nil
+}
+
+// MarshalXML is implementation of xml.Marshaler.
+// It encodes Money as an element holding the amount in major units with the
+// currency code in the Ccy attribute, e.g. <Amt Ccy="EUR">12.34</Amt>.
+func (m Money) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if m == (Money{}) {
+		m = *New(0, "")
+	}
+
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: xmlCurrencyAttr}, Value: m.currency.Code})
+	return e.EncodeElement(formatMajorUnits(m.amount, m.currency.get().Fraction), start)
+}
+
+// UnmarshalXML is implementation of xml.Unmarshaler.
+// It decodes Money from the element form produced by MarshalXML, and a zero
+// amount with an empty Ccy attribute to the zero Money.
+func (m *Money) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var v struct {
+		Currency string `xml:"Ccy,attr"`
+		Amount   string `xml:",chardata"`
+	}
+	if err := d.DecodeElement(&v, &start); err != nil {
+		return err
+	}
+
+	om, err := decodeMajorUnits(strings.TrimSpace(v.Amount), strings.TrimSpace(v.Currency))
+	if err != nil {
+		return wrapError(ErrInvalidTextUnmarshal, fmt.Errorf("element <%s>: %w", start.Name.Local, err))
+	}
+
+	*m = om
+	return nil
+}
+
+// MarshalXMLAttr is implementation of xml.MarshalerAttr.
+// It encodes Money as an attribute in the format produced by MarshalText, e.g. amount="12.34 USD".
+func (m Money) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	b, err := m.MarshalText()
+	if err != nil {
+		return xml.Attr{}, err
+	}
+
+	return xml.Attr{Name: name, Value: string(b)}, nil
+}
+
+// UnmarshalXMLAttr is implementation of xml.UnmarshalerAttr.
+// It decodes Money from the attribute form produced by MarshalXMLAttr.
+func (m *Money) UnmarshalXMLAttr(attr xml.Attr) error {
+	return m.UnmarshalText([]byte(attr.Value))
+}
+
+// MarshalText is implementation of encoding.TextMarshaler.
+// It encodes Currency as its code, e.g. "USD".
+func (c Currency) MarshalText() ([]byte, error) {
+	return []byte(c.Code), nil
+}
+
+// UnmarshalText is implementation of encoding.TextUnmarshaler.
+// It decodes a registered Currency from its code, e.g. "USD".
+func (c *Currency) UnmarshalText(b []byte) error {
+	val := GetCurrency(string(b))
+	if val == nil {
+		return fmt.Errorf("%w: %q", ErrUnknownCurrency, b)
+	}
+
+	*c = *val
+	return nil
+}
+
+// currencyJSON has the fields of Currency without its methods, so it keeps the
+// default JSON object encoding that MarshalText would otherwise replace.
+type currencyJSON Currency
+
+// MarshalJSON is implementation of json.Marshaller.
+// It encodes Currency as a JSON object of its fields.
+func (c Currency) MarshalJSON() ([]byte, error) {
+	return json.Marshal(currencyJSON(c))
+}
+
+// UnmarshalJSON is implementation of json.Unmarshaller.
+// It decodes Currency from a JSON object of its fields.
+func (c *Currency) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, (*currencyJSON)(c))
+}
@@ -0,0 +1,197 @@
+package money
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"testing"
+)
+
+func TestMoney_MarshalText(t *testing.T) {
+	tcs := []struct {
+		money    *Money
+		expected string
+	}{
+		{New(1234, USD), "12.34 USD"},
+		{New(-5, EUR), "-0.05 EUR"},
+		{New(1234, JPY), "1234 JPY"},
+		{New(1, KWD), "0.001 KWD"},
+		{&Money{}, "0.00 "},
+	}
+
+	for _, tc := range tcs {
+		b, err := tc.money.MarshalText()
+		if err != nil {
+			t.Error(err)
+		}
+
+		if string(b) != tc.expected {
+			t.Errorf("Expected %s got %s", tc.expected, string(b))
+		}
+
+		var got Money
+		if err := got.UnmarshalText(b); err != nil || got != *tc.money {
+			t.Errorf("Expected %q to round trip got %+v, %v", string(b), got, err)
+		}
+	}
+}
+
+func TestMoney_UnmarshalText(t *testing.T) {
+	tcs := []struct {
+		given    string
+		amount   int64
+		currency string
+		err      bool
+	}{
+		{given: "12.34 USD", amount: 1234, currency: USD},
+		{given: "-0.05 EUR", amount: -5, currency: EUR},
+		{given: "1234 JPY", amount: 1234, currency: JPY},
+		{given: "1 usd", amount: 100, currency: USD},
+		{given: "12.345 USD", err: true},
+		{given: "12.34", err: true},
+		{given: "0.00 "},
+		{given: "0 "},
+		{given: "12.34 ", err: true},
+		{given: "0.00X ", err: true},
+		{given: " USD", err: true},
+		{given: "12,34 EUR", err: true},
+	}
+
+	for _, tc := range tcs {
+		var m Money
+		err := m.UnmarshalText([]byte(tc.given))
+		if tc.err {
+			if !errors.Is(err, ErrInvalidTextUnmarshal) {
+				t.Errorf("UnmarshalText(%q) expected ErrInvalidTextUnmarshal, got %v", tc.given, err)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("UnmarshalText(%q) error: %v", tc.given, err)
+			continue
+		}
+
+		if tc.currency == "" {
+			if m != (Money{}) {
+				t.Errorf("UnmarshalText(%q) expected the zero Money got %+v", tc.given, m)
+			}
+			continue
+		}
+		if m.Amount() != tc.amount || m.Currency().Code != tc.currency {
+			t.Errorf("UnmarshalText(%q) expected %d %s got %d %s", tc.given, tc.amount, tc.currency, m.Amount(), m.Currency().Code)
+		}
+	}
+}
+
+func TestMoney_JSONMapKey(t *testing.T) {
+	given := map[Money]int{*New(1234, USD): 1}
+	expected := `{"12.34 USD":1}`
+
+	b, err := json.Marshal(given)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expected {
+		t.Fatalf("Expected %s got %s", expected, string(b))
+	}
+
+	var got map[Money]int
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got[*New(1234, USD)] != 1 {
+		t.Errorf("Expected %v got %v", given, got)
+	}
+}
+
+func TestMoney_XML(t *testing.T) {
+	type payment struct {
+		XMLName xml.Name `xml:"Pmt"`
+		Limit   *Money   `xml:"Lmt,attr,omitempty"`
+		Amount  Money    `xml:"Amt"`
+	}
+
+	given := payment{Limit: New(50000, USD), Amount: *New(1234, EUR)}
+	expected := `<Pmt Lmt="500.00 USD"><Amt Ccy="EUR">12.34</Amt></Pmt>`
+
+	b, err := xml.Marshal(given)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expected {
+		t.Fatalf("Expected %s got %s", expected, string(b))
+	}
+
+	var got payment
+	if err := xml.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if eq, err := got.Amount.Equals(&given.Amount); err != nil || !eq {
+		t.Errorf("Expected %s got %s", given.Amount.Display(), got.Amount.Display())
+	}
+	if eq, err := got.Limit.Equals(given.Limit); err != nil || !eq {
+		t.Errorf("Expected %s got %s", given.Limit.Display(), got.Limit.Display())
+	}
+
+	b, err = xml.Marshal(payment{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var zero payment
+	if err := xml.Unmarshal(b, &zero); err != nil || zero.Amount != (Money{}) || zero.Limit != nil {
+		t.Errorf("Expected %s to decode to the zero Money got %+v, %v", string(b), zero, err)
+	}
+
+	for _, tc := range []string{
+		`<Pmt><Amt>12.34</Amt></Pmt>`,
+		`<Pmt><Amt Ccy="EUR">12.345</Amt></Pmt>`,
+		`<Pmt Lmt="500.00"><Amt Ccy="EUR">1</Amt></Pmt>`,
+	} {
+		if err := xml.Unmarshal([]byte(tc), &got); !errors.Is(err, ErrInvalidTextUnmarshal) {
+			t.Errorf("Unmarshal(%s) expected ErrInvalidTextUnmarshal, got %v", tc, err)
+		}
+	}
+}
+
+func TestCurrency_Text(t *testing.T) {
+	b, err := GetCurrency(EUR).MarshalText()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != EUR {
+		t.Errorf("Expected %s got %s", EUR, string(b))
+	}
+
+	var c Currency
+	if err := c.UnmarshalText([]byte("eur")); err != nil {
+		t.Fatal(err)
+	}
+	if c != *GetCurrency(EUR) {
+		t.Errorf("Expected %+v got %+v", *GetCurrency(EUR), c)
+	}
+
+	if err := c.UnmarshalText([]byte("XYZ")); !errors.Is(err, ErrUnknownCurrency) {
+		t.Errorf("Expected ErrUnknownCurrency, got %v", err)
+	}
+}
+
+func TestCurrency_JSONKeepsObject(t *testing.T) {
+	expected := `{"Code":"EUR","NumericCode":"978","Fraction":2,"Grapheme":"€","Template":"$1","Decimal":".","Thousand":","}`
+
+	b, err := json.Marshal(GetCurrency(EUR))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != expected {
+		t.Fatalf("Expected %s got %s", expected, string(b))
+	}
+
+	var c Currency
+	if err := json.Unmarshal(b, &c); err != nil {
+		t.Fatal(err)
+	}
+	if c != *GetCurrency(EUR) {
+		t.Errorf("Expected %+v got %+v", *GetCurrency(EUR), c)
+	}
+}